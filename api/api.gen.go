@@ -6,27 +6,265 @@ package api
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-// Error The default error returned
-type Error struct {
-	// Details The details about the error.
-	Details string `json:"details"`
+// Defines values for BookingStatus.
+const (
+	Cancelled BookingStatus = "cancelled"
+	Confirmed BookingStatus = "confirmed"
+	Pending   BookingStatus = "pending"
+)
 
-	// Status The HTTP status code returned.
-	Status int `json:"status"`
+// Defines values for DistanceUnit.
+const (
+	Km DistanceUnit = "km"
+	Mi DistanceUnit = "mi"
+)
 
-	// Title Error title.
-	Title string `json:"title"`
+// Defines values for GeoJSONGeometryType.
+const (
+	MultiPolygon GeoJSONGeometryType = "MultiPolygon"
+	Polygon      GeoJSONGeometryType = "Polygon"
+)
+
+// Defines values for LineItemType.
+const (
+	CleaningFee     LineItemType = "cleaning_fee"
+	ExtraGuests     LineItemType = "extra_guests"
+	MonthlyDiscount LineItemType = "monthly_discount"
+	Nightly         LineItemType = "nightly"
+	Seasonal        LineItemType = "seasonal"
+	Tax             LineItemType = "tax"
+	Weekend         LineItemType = "weekend"
+	WeeklyDiscount  LineItemType = "weekly_discount"
+)
+
+// Defines values for ProblemCode.
+const (
+	BookingNotFound       ProblemCode = "booking_not_found"
+	BookingStatusConflict ProblemCode = "booking_status_conflict"
+	Conflict              ProblemCode = "conflict"
+	ExchangeRatesNotFound ProblemCode = "exchange_rates_not_found"
+	InternalError         ProblemCode = "internal_error"
+	InvalidRequest        ProblemCode = "invalid_request"
+	MethodNotAllowed      ProblemCode = "method_not_allowed"
+	NotFound              ProblemCode = "not_found"
+	RentalNotFound        ProblemCode = "rental_not_found"
+	RentalUnavailable     ProblemCode = "rental_unavailable"
+	RequestCanceled       ProblemCode = "request_canceled"
+	Timeout               ProblemCode = "timeout"
+	Unauthorized          ProblemCode = "unauthorized"
+	Unavailable           ProblemCode = "unavailable"
+	UserNotFound          ProblemCode = "user_not_found"
+)
+
+// Defines values for UnavailablePeriodReason.
+const (
+	Blocked UnavailablePeriodReason = "blocked"
+	Booked  UnavailablePeriodReason = "booked"
+)
+
+// Defines values for GetRentalsParamsSort.
+const (
+	GetRentalsParamsSortCreated        GetRentalsParamsSort = "created"
+	GetRentalsParamsSortDistance       GetRentalsParamsSort = "distance"
+	GetRentalsParamsSortLength         GetRentalsParamsSort = "length"
+	GetRentalsParamsSortMinusCreated   GetRentalsParamsSort = "-created"
+	GetRentalsParamsSortMinusDistance  GetRentalsParamsSort = "-distance"
+	GetRentalsParamsSortMinusLength    GetRentalsParamsSort = "-length"
+	GetRentalsParamsSortMinusName      GetRentalsParamsSort = "-name"
+	GetRentalsParamsSortMinusPrice     GetRentalsParamsSort = "-price"
+	GetRentalsParamsSortMinusRelevance GetRentalsParamsSort = "-relevance"
+	GetRentalsParamsSortMinusSleeps    GetRentalsParamsSort = "-sleeps"
+	GetRentalsParamsSortMinusYear      GetRentalsParamsSort = "-year"
+	GetRentalsParamsSortName           GetRentalsParamsSort = "name"
+	GetRentalsParamsSortPrice          GetRentalsParamsSort = "price"
+	GetRentalsParamsSortRelevance      GetRentalsParamsSort = "relevance"
+	GetRentalsParamsSortSleeps         GetRentalsParamsSort = "sleeps"
+	GetRentalsParamsSortYear           GetRentalsParamsSort = "year"
+)
+
+// Defines values for SearchRentalsParamsSort.
+const (
+	SearchRentalsParamsSortCreated        SearchRentalsParamsSort = "created"
+	SearchRentalsParamsSortDistance       SearchRentalsParamsSort = "distance"
+	SearchRentalsParamsSortLength         SearchRentalsParamsSort = "length"
+	SearchRentalsParamsSortMinusCreated   SearchRentalsParamsSort = "-created"
+	SearchRentalsParamsSortMinusDistance  SearchRentalsParamsSort = "-distance"
+	SearchRentalsParamsSortMinusLength    SearchRentalsParamsSort = "-length"
+	SearchRentalsParamsSortMinusName      SearchRentalsParamsSort = "-name"
+	SearchRentalsParamsSortMinusPrice     SearchRentalsParamsSort = "-price"
+	SearchRentalsParamsSortMinusRelevance SearchRentalsParamsSort = "-relevance"
+	SearchRentalsParamsSortMinusSleeps    SearchRentalsParamsSort = "-sleeps"
+	SearchRentalsParamsSortMinusYear      SearchRentalsParamsSort = "-year"
+	SearchRentalsParamsSortName           SearchRentalsParamsSort = "name"
+	SearchRentalsParamsSortPrice          SearchRentalsParamsSort = "price"
+	SearchRentalsParamsSortRelevance      SearchRentalsParamsSort = "relevance"
+	SearchRentalsParamsSortSleeps         SearchRentalsParamsSort = "sleeps"
+	SearchRentalsParamsSortYear           SearchRentalsParamsSort = "year"
+)
+
+// Defines values for GetUserRentalsParamsSort.
+const (
+	GetUserRentalsParamsSortCreated        GetUserRentalsParamsSort = "created"
+	GetUserRentalsParamsSortDistance       GetUserRentalsParamsSort = "distance"
+	GetUserRentalsParamsSortLength         GetUserRentalsParamsSort = "length"
+	GetUserRentalsParamsSortMinusCreated   GetUserRentalsParamsSort = "-created"
+	GetUserRentalsParamsSortMinusDistance  GetUserRentalsParamsSort = "-distance"
+	GetUserRentalsParamsSortMinusLength    GetUserRentalsParamsSort = "-length"
+	GetUserRentalsParamsSortMinusName      GetUserRentalsParamsSort = "-name"
+	GetUserRentalsParamsSortMinusPrice     GetUserRentalsParamsSort = "-price"
+	GetUserRentalsParamsSortMinusRelevance GetUserRentalsParamsSort = "-relevance"
+	GetUserRentalsParamsSortMinusSleeps    GetUserRentalsParamsSort = "-sleeps"
+	GetUserRentalsParamsSortMinusYear      GetUserRentalsParamsSort = "-year"
+	GetUserRentalsParamsSortName           GetUserRentalsParamsSort = "name"
+	GetUserRentalsParamsSortPrice          GetUserRentalsParamsSort = "price"
+	GetUserRentalsParamsSortRelevance      GetUserRentalsParamsSort = "relevance"
+	GetUserRentalsParamsSortSleeps         GetUserRentalsParamsSort = "sleeps"
+	GetUserRentalsParamsSortYear           GetUserRentalsParamsSort = "year"
+)
+
+// Availability The availability of a rental within a range of days.
+type Availability struct {
+	// Available Whether the rental can be booked for the whole range.
+	Available bool `json:"available"`
+
+	// From The first day of the range.
+	From openapi_types.Date `json:"from"`
+
+	// RentalId The rental id.
+	RentalId int `json:"rental_id"`
+
+	// To The day after the last day of the range.
+	To openapi_types.Date `json:"to"`
+
+	// Unavailable The periods within the range the rental can not be booked for, ordered by start.
+	Unavailable []UnavailablePeriod `json:"unavailable"`
+}
+
+// Booking A booking of a rental.
+type Booking struct {
+	// Created When the booking was created.
+	Created time.Time `json:"created"`
+
+	// Currency The ISO 4217 code of the currency of the total, the currency of the rental when it was booked.
+	Currency string `json:"currency"`
+
+	// EndDate The check-out day, the rental is not booked on this day.
+	EndDate openapi_types.Date `json:"end_date"`
+
+	// Id The booking id.
+	Id int `json:"id"`
+
+	// Nights The number of nights booked.
+	Nights int `json:"nights"`
+
+	// RentalId The id of the booked rental.
+	RentalId int `json:"rental_id"`
+
+	// StartDate The check-in day.
+	StartDate openapi_types.Date `json:"start_date"`
+
+	// Status The booking status.
+	Status BookingStatus `json:"status"`
+
+	// Total The price of the whole booking, in cents of the currency.
+	Total int64 `json:"total"`
+
+	// Updated When the booking status last changed.
+	Updated time.Time `json:"updated"`
+
+	// UserId The id of the user renting.
+	UserId int `json:"user_id"`
+}
+
+// BookingInput A request to book a rental.
+type BookingInput struct {
+	// EndDate The check-out day, the rental is not booked on this day.
+	EndDate openapi_types.Date `json:"end_date"`
+
+	// StartDate The check-in day.
+	StartDate openapi_types.Date `json:"start_date"`
+
+	// UserId The id of the user renting.
+	UserId int `json:"user_id"`
+}
+
+// BookingStatus The booking status.
+type BookingStatus string
+
+// BookingStatusUpdate The new status of a booking.
+type BookingStatusUpdate struct {
+	// Status The booking status.
+	Status BookingStatus `json:"status"`
+}
+
+// DistanceUnit The unit of a distance, miles or kilometers.
+type DistanceUnit string
+
+// FacetCount The number of matching rentals with a value. Facets list the most common values first.
+type FacetCount struct {
+	Count int    `json:"count"`
+	Value string `json:"value"`
+}
+
+// FieldError A validation error for a single request field.
+type FieldError struct {
+	// Field The name of the invalid field.
+	Field string `json:"field"`
+
+	// Message Why the field was rejected.
+	Message string `json:"message"`
+}
+
+// GeoJSONGeometry A GeoJSON Polygon or MultiPolygon with [lng, lat] positions.
+type GeoJSONGeometry struct {
+	// Coordinates The closed linear rings of a Polygon, or a list of those for a MultiPolygon.
+	Coordinates json.RawMessage `json:"coordinates"`
+
+	// Type The GeoJSON geometry type.
+	Type GeoJSONGeometryType `json:"type"`
+}
+
+// GeoJSONGeometryType The GeoJSON geometry type.
+type GeoJSONGeometryType string
+
+// LineItem One charge of a quote.
+type LineItem struct {
+	// Amount The quantity times the unit amount, in cents. Negative for discounts.
+	Amount int64 `json:"amount"`
+
+	// Description A human readable description of the charge.
+	Description string `json:"description"`
+
+	// Quantity The number of units charged, like nights.
+	Quantity int `json:"quantity"`
+
+	// Type The kind of charge.
+	Type LineItemType `json:"type"`
+
+	// UnitAmount The price of a single unit, in cents.
+	UnitAmount int64 `json:"unit_amount"`
 }
 
+// LineItemType The kind of charge.
+type LineItemType string
+
 // Location The rental location.
 type Location struct {
 	// City The rental city.
@@ -48,17 +286,149 @@ type Location struct {
 	Zip string `json:"zip"`
 }
 
+// LocationPatch The fields of the rental location to update, fields that are not present are kept.
+type LocationPatch struct {
+	// City The rental city.
+	City *string `json:"city,omitempty"`
+
+	// Country The rental country.
+	Country *string `json:"country,omitempty"`
+
+	// Lat The rental latitude.
+	Lat *float64 `json:"lat,omitempty"`
+
+	// Lng The rental longitude.
+	Lng *float64 `json:"lng,omitempty"`
+
+	// State The rental state.
+	State *string `json:"state,omitempty"`
+
+	// Zip The rental zip.
+	Zip *string `json:"zip,omitempty"`
+}
+
 // Price The rental price.
 type Price struct {
-	// Day The rental price per day.
+	// Currency The ISO 4217 code of the currency of the price. Rentals are created in USD when it is missing.
+	Currency *string `json:"currency,omitempty"`
+
+	// Day The rental price per day, in cents.
 	Day int64 `json:"day"`
 }
 
+// PriceBucket The number of rentals priced from `min` up to, but excluding, `max`.
+type PriceBucket struct {
+	Count int   `json:"count"`
+	Max   int64 `json:"max"`
+	Min   int64 `json:"min"`
+}
+
+// PriceStats The distribution of the prices per day of the matching rentals, in cents and in the requested currency like the price filters. Missing when no rental matches.
+type PriceStats struct {
+	// Histogram The prices from `min` to `max` split into at most 10 equally wide buckets, including empty ones.
+	Histogram []PriceBucket `json:"histogram"`
+	Max       int64         `json:"max"`
+	Median    int64         `json:"median"`
+	Min       int64         `json:"min"`
+
+	// P25 The price at or below which a quarter of the rentals are priced.
+	P25 int64 `json:"p25"`
+
+	// P75 The price at or below which three quarters of the rentals are priced.
+	P75 int64 `json:"p75"`
+}
+
+// Problem An RFC 7807 problem details object, returned with the application/problem+json content type for every error.
+type Problem struct {
+	// Code The stable, machine-readable code of the problem. Unlike the detail, it does not change between releases.
+	// - `invalid_request` the request is invalid, `errors` lists its invalid fields.
+	// - `not_found` the path does not exist.
+	// - `method_not_allowed` the path does not support the method.
+	// - `rental_not_found`, `user_not_found`, `booking_not_found` the resource does not exist.
+	// - `exchange_rates_not_found` there are no exchange rates for the currency.
+	// - `rental_unavailable` the rental is already booked for some of the dates.
+	// - `booking_status_conflict` the booking can not change to the status.
+	// - `conflict` the request conflicts with the state of a resource.
+	// - `unauthorized` the request lacks valid credentials.
+	// - `request_canceled` the client gave up on the request before it was served.
+	// - `unavailable` a dependency of the API is unavailable, the request may succeed when it is retried.
+	// - `timeout` the request timed out, it may succeed when it is retried.
+	// - `internal_error` the request failed on the server.
+	Code ProblemCode `json:"code"`
+
+	// Detail The details about this occurrence of the problem, meant for humans.
+	Detail string `json:"detail"`
+
+	// Errors The invalid request fields, returned for the invalid_request problems.
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance The path of the request the problem occurred for.
+	Instance string `json:"instance"`
+
+	// Status The HTTP status code returned.
+	Status int `json:"status"`
+
+	// Title The title of the HTTP status code returned.
+	Title string `json:"title"`
+
+	// Type The type of the problem, about:blank as the code tells the problems apart.
+	Type string `json:"type"`
+}
+
+// ProblemCode The stable, machine-readable code of the problem. Unlike the detail, it does not change between releases.
+// - `invalid_request` the request is invalid, `errors` lists its invalid fields.
+// - `not_found` the path does not exist.
+// - `method_not_allowed` the path does not support the method.
+// - `rental_not_found`, `user_not_found`, `booking_not_found` the resource does not exist.
+// - `exchange_rates_not_found` there are no exchange rates for the currency.
+// - `rental_unavailable` the rental is already booked for some of the dates.
+// - `booking_status_conflict` the booking can not change to the status.
+// - `conflict` the request conflicts with the state of a resource.
+// - `unauthorized` the request lacks valid credentials.
+// - `request_canceled` the client gave up on the request before it was served.
+// - `unavailable` a dependency of the API is unavailable, the request may succeed when it is retried.
+// - `timeout` the request timed out, it may succeed when it is retried.
+// - `internal_error` the request failed on the server.
+type ProblemCode string
+
+// Quote The price of a stay at a rental.
+type Quote struct {
+	// Currency The ISO 4217 code of the currency of all amounts, the currency of the rental.
+	Currency string `json:"currency"`
+
+	// End The check-out day.
+	End openapi_types.Date `json:"end"`
+
+	// Guests The number of guests.
+	Guests int `json:"guests"`
+
+	// LineItems The charges of the stay, adding up to the total.
+	LineItems []LineItem `json:"line_items"`
+
+	// Nights The number of nights of the stay.
+	Nights int `json:"nights"`
+
+	// RentalId The rental id.
+	RentalId int `json:"rental_id"`
+
+	// Start The check-in day.
+	Start openapi_types.Date `json:"start"`
+
+	// Total The price of the whole stay, in cents.
+	Total int64 `json:"total"`
+}
+
 // Rental A rental object.
 type Rental struct {
+	// Created When the rental was created.
+	Created *time.Time `json:"created,omitempty"`
+
 	// Description The rental description.
 	Description string `json:"description"`
 
+	// Distance The distance from the `near` point in the requested unit, only returned when searching with `near`.
+	Distance *float64 `json:"distance,omitempty"`
+
 	// Id The rental id.
 	Id int `json:"id"`
 
@@ -89,14 +459,140 @@ type Rental struct {
 	// Type The rental type.
 	Type string `json:"type"`
 
-	// User The rental user.
+	// Updated When the rental was last updated.
+	Updated *time.Time `json:"updated,omitempty"`
+
+	// User A user owning rentals.
 	User User `json:"user"`
 
 	// Year The rental year.
 	Year int `json:"year"`
 }
 
-// User The rental user.
+// RentalFacets The counts and price distribution of the rentals matching a search.
+type RentalFacets struct {
+	// Countries The matching rentals per country.
+	Countries []FacetCount `json:"countries"`
+
+	// Makes The matching rentals per make regardless of case, named by its most common spelling.
+	Makes []FacetCount `json:"makes"`
+
+	// Price The distribution of the prices per day of the matching rentals, in cents and in the requested currency like the price filters. Missing when no rental matches.
+	Price *PriceStats `json:"price,omitempty"`
+
+	// Sleeps The matching rentals per number of people they sleep.
+	Sleeps []FacetCount `json:"sleeps"`
+
+	// States The matching rentals per state, named by the country and state joined by a dash, like `US-CA`.
+	States []FacetCount `json:"states"`
+
+	// Total The number of matching rentals.
+	Total int `json:"total"`
+
+	// Types The matching rentals per type.
+	Types []FacetCount `json:"types"`
+}
+
+// RentalInput The editable fields of a rental.
+type RentalInput struct {
+	// Description The rental description.
+	Description string `json:"description"`
+
+	// Length The rental length.
+	Length float32 `json:"length"`
+
+	// Location The rental location.
+	Location Location `json:"location"`
+
+	// Make The rental make.
+	Make string `json:"make"`
+
+	// Model The rental model.
+	Model string `json:"model"`
+
+	// Name The rental name.
+	Name string `json:"name"`
+
+	// Price The rental price.
+	Price Price `json:"price"`
+
+	// PrimaryImageUrl The rental primary image url.
+	PrimaryImageUrl string `json:"primary_image_url"`
+
+	// Sleeps The rental sleeps.
+	Sleeps int `json:"sleeps"`
+
+	// Type The rental type.
+	Type string `json:"type"`
+
+	// UserId The id of the user owning the rental.
+	UserId int `json:"user_id"`
+
+	// Year The rental year.
+	Year int `json:"year"`
+}
+
+// RentalPatch The editable fields of a rental to update, fields that are not present are kept.
+type RentalPatch struct {
+	// Description The rental description.
+	Description *string `json:"description,omitempty"`
+
+	// Length The rental length.
+	Length *float32 `json:"length,omitempty"`
+
+	// Location The fields of the rental location to update, fields that are not present are kept.
+	Location *LocationPatch `json:"location,omitempty"`
+
+	// Make The rental make.
+	Make *string `json:"make,omitempty"`
+
+	// Model The rental model.
+	Model *string `json:"model,omitempty"`
+
+	// Name The rental name.
+	Name *string `json:"name,omitempty"`
+
+	// Price The rental price.
+	Price *Price `json:"price,omitempty"`
+
+	// PrimaryImageUrl The rental primary image url.
+	PrimaryImageUrl *string `json:"primary_image_url,omitempty"`
+
+	// Sleeps The rental sleeps.
+	Sleeps *int `json:"sleeps,omitempty"`
+
+	// Type The rental type.
+	Type *string `json:"type,omitempty"`
+
+	// UserId The id of the user owning the rental.
+	UserId *int `json:"user_id,omitempty"`
+
+	// Year The rental year.
+	Year *int `json:"year,omitempty"`
+}
+
+// RentalsSearch A search for rentals within an area.
+type RentalsSearch struct {
+	// Geometry A GeoJSON Polygon or MultiPolygon with [lng, lat] positions.
+	Geometry GeoJSONGeometry `json:"geometry"`
+}
+
+// UnavailablePeriod A period in which a rental is booked or blocked by its owner.
+type UnavailablePeriod struct {
+	// EndDate The day after the last unavailable day.
+	EndDate openapi_types.Date `json:"end_date"`
+
+	// Reason Why the rental is unavailable.
+	Reason UnavailablePeriodReason `json:"reason"`
+
+	// StartDate The first unavailable day.
+	StartDate openapi_types.Date `json:"start_date"`
+}
+
+// UnavailablePeriodReason Why the rental is unavailable.
+type UnavailablePeriodReason string
+
+// User A user owning rentals.
 type User struct {
 	// FirstName The rental user first name.
 	FirstName string `json:"first_name"`
@@ -108,54 +604,2886 @@ type User struct {
 	LastName string `json:"last_name"`
 }
 
-// GetV1RentalsParams defines parameters for GetV1Rentals.
-type GetV1RentalsParams struct {
+// BBox defines model for BBox.
+type BBox = []float64
+
+// BookingId defines model for BookingId.
+type BookingId = int
+
+// Currency defines model for Currency.
+type Currency = string
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// EndDate defines model for EndDate.
+type EndDate = openapi_types.Date
+
+// Ids defines model for Ids.
+type Ids = []int
+
+// LengthMax defines model for LengthMax.
+type LengthMax = float64
+
+// LengthMin defines model for LengthMin.
+type LengthMin = float64
+
+// Limit defines model for Limit.
+type Limit = int
+
+// Make defines model for Make.
+type Make = string
+
+// Model defines model for Model.
+type Model = string
+
+// Near defines model for Near.
+type Near = []float64
+
+// Offset defines model for Offset.
+type Offset = int
+
+// PriceMax defines model for PriceMax.
+type PriceMax = int64
+
+// PriceMin defines model for PriceMin.
+type PriceMin = int64
+
+// Query defines model for Query.
+type Query = string
+
+// Radius defines model for Radius.
+type Radius = float64
+
+// RentalId defines model for RentalId.
+type RentalId = int
+
+// SleepsMin defines model for SleepsMin.
+type SleepsMin = int
+
+// Sort defines model for Sort.
+type Sort = []string
+
+// StartDate defines model for StartDate.
+type StartDate = openapi_types.Date
+
+// Type defines model for Type.
+type Type = []string
+
+// Unit The unit of a distance, miles or kilometers.
+type Unit = DistanceUnit
+
+// UserId defines model for UserId.
+type UserId = int
+
+// YearMax defines model for YearMax.
+type YearMax = int
+
+// YearMin defines model for YearMin.
+type YearMin = int
+
+// GetRentalsParams defines parameters for GetRentals.
+type GetRentalsParams struct {
 	// PriceMin The minimum price of the rental.
-	PriceMin *int64 `form:"price_min,omitempty" json:"price_min,omitempty"`
+	PriceMin *PriceMin `form:"price_min,omitempty" json:"price_min,omitempty"`
 
 	// PriceMax The maximum price of the rental.
-	PriceMax *int64 `form:"price_max,omitempty" json:"price_max,omitempty"`
+	PriceMax *PriceMax `form:"price_max,omitempty" json:"price_max,omitempty"`
 
 	// Limit The maximum number of rentals to return.
-	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
 
 	// Offset The offset of the rentals to return.
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor The opaque cursor from the X-Next-Cursor header of the previous page. Returns the rentals sorted after it, unaffected by rentals added or removed in between. Must be sent with the same filters and sort as the previous page and can not be combined with offset.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Ids The comma separated list of rental ids to return.
+	Ids *Ids `form:"ids,omitempty" json:"ids,omitempty"`
+
+	// Near The comma separated pair [lat,lng] to return rentals near.
+	Near *Near `form:"near,omitempty" json:"near,omitempty"`
+
+	// Radius The search radius around `near`, in `unit`. Defaults to 100.
+	Radius *Radius `form:"radius,omitempty" json:"radius,omitempty"`
+
+	// Unit The unit of `radius` and of the returned rental distances. Defaults to miles.
+	Unit *Unit `form:"unit,omitempty" json:"unit,omitempty"`
+
+	// Bbox The comma separated map viewport [minLng,minLat,maxLng,maxLat] to return rentals within. A minLng greater than maxLng selects a box crossing the antimeridian.
+	Bbox *BBox `form:"bbox,omitempty" json:"bbox,omitempty"`
+
+	// StartDate Only returns rentals that can be booked from this day, the check-in day. Requires `end_date`.
+	StartDate *StartDate `form:"start_date,omitempty" json:"start_date,omitempty"`
+
+	// EndDate Only returns rentals that can be booked until this day, the check-out day, which stays free for the next booking. Requires `start_date`.
+	EndDate *EndDate `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// Type The comma separated list of rental types to return rentals of any of.
+	Type *Type `form:"type,omitempty" json:"type,omitempty"`
+
+	// Make Only returns rentals of the vehicle make, regardless of case.
+	Make *Make `form:"make,omitempty" json:"make,omitempty"`
+
+	// Model Only returns rentals of the vehicle model, regardless of case.
+	Model *Model `form:"model,omitempty" json:"model,omitempty"`
+
+	// YearMin The minimum vehicle year of the rental.
+	YearMin *YearMin `form:"year_min,omitempty" json:"year_min,omitempty"`
+
+	// YearMax The maximum vehicle year of the rental.
+	YearMax *YearMax `form:"year_max,omitempty" json:"year_max,omitempty"`
+
+	// SleepsMin The minimum number of people the rental sleeps.
+	SleepsMin *SleepsMin `form:"sleeps_min,omitempty" json:"sleeps_min,omitempty"`
+
+	// LengthMin The minimum vehicle length of the rental, in feet.
+	LengthMin *LengthMin `form:"length_min,omitempty" json:"length_min,omitempty"`
+
+	// LengthMax The maximum vehicle length of the rental, in feet.
+	LengthMax *LengthMax `form:"length_max,omitempty" json:"length_max,omitempty"`
+
+	// Q Only returns rentals whose name, description, make or model match all words of the full-text search, regardless of case and word form. Quoted phrases and `or` are supported. Makes and models also match with small typos and by their known aliases, like `VW` for `Volkswagen`.
+	Q *Query `form:"q,omitempty" json:"q,omitempty"`
+
+	// Sort The comma separated list of fields to sort the rentals by. A leading `-` sorts the field in descending order. Sorting by `distance` requires `near`. Sorting by `relevance` requires `q` and puts the best matches first, `-relevance` reverses it. Ties are broken by the rental id.
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Currency Converts the price of the rentals into the currency, an ISO 4217 code. With a currency `price_min`, `price_max` and the price sort use the converted prices too, and rentals priced in a currency without an exchange rate are left out. Without it every rental is priced in its own currency.
+	Currency *Currency `form:"currency,omitempty" json:"currency,omitempty"`
+}
+
+// GetRentalsParamsSort defines parameters for GetRentals.
+type GetRentalsParamsSort string
+
+// GetRentalFacetsParams defines parameters for GetRentalFacets.
+type GetRentalFacetsParams struct {
+	// PriceMin The minimum price of the rental.
+	PriceMin *PriceMin `form:"price_min,omitempty" json:"price_min,omitempty"`
+
+	// PriceMax The maximum price of the rental.
+	PriceMax *PriceMax `form:"price_max,omitempty" json:"price_max,omitempty"`
 
 	// Ids The comma separated list of rental ids to return.
-	Ids *[]int `json:"ids,omitempty"`
+	Ids *Ids `form:"ids,omitempty" json:"ids,omitempty"`
 
 	// Near The comma separated pair [lat,lng] to return rentals near.
-	Near *[]float64 `form:"near,omitempty" json:"near,omitempty"`
+	Near *Near `form:"near,omitempty" json:"near,omitempty"`
+
+	// Radius The search radius around `near`, in `unit`. Defaults to 100.
+	Radius *Radius `form:"radius,omitempty" json:"radius,omitempty"`
+
+	// Unit The unit of `radius` and of the returned rental distances. Defaults to miles.
+	Unit *Unit `form:"unit,omitempty" json:"unit,omitempty"`
+
+	// Bbox The comma separated map viewport [minLng,minLat,maxLng,maxLat] to return rentals within. A minLng greater than maxLng selects a box crossing the antimeridian.
+	Bbox *BBox `form:"bbox,omitempty" json:"bbox,omitempty"`
+
+	// StartDate Only returns rentals that can be booked from this day, the check-in day. Requires `end_date`.
+	StartDate *StartDate `form:"start_date,omitempty" json:"start_date,omitempty"`
+
+	// EndDate Only returns rentals that can be booked until this day, the check-out day, which stays free for the next booking. Requires `start_date`.
+	EndDate *EndDate `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// Type The comma separated list of rental types to return rentals of any of.
+	Type *Type `form:"type,omitempty" json:"type,omitempty"`
+
+	// Make Only returns rentals of the vehicle make, regardless of case.
+	Make *Make `form:"make,omitempty" json:"make,omitempty"`
+
+	// Model Only returns rentals of the vehicle model, regardless of case.
+	Model *Model `form:"model,omitempty" json:"model,omitempty"`
+
+	// YearMin The minimum vehicle year of the rental.
+	YearMin *YearMin `form:"year_min,omitempty" json:"year_min,omitempty"`
+
+	// YearMax The maximum vehicle year of the rental.
+	YearMax *YearMax `form:"year_max,omitempty" json:"year_max,omitempty"`
+
+	// SleepsMin The minimum number of people the rental sleeps.
+	SleepsMin *SleepsMin `form:"sleeps_min,omitempty" json:"sleeps_min,omitempty"`
+
+	// LengthMin The minimum vehicle length of the rental, in feet.
+	LengthMin *LengthMin `form:"length_min,omitempty" json:"length_min,omitempty"`
+
+	// LengthMax The maximum vehicle length of the rental, in feet.
+	LengthMax *LengthMax `form:"length_max,omitempty" json:"length_max,omitempty"`
+
+	// Q Only returns rentals whose name, description, make or model match all words of the full-text search, regardless of case and word form. Quoted phrases and `or` are supported. Makes and models also match with small typos and by their known aliases, like `VW` for `Volkswagen`.
+	Q *Query `form:"q,omitempty" json:"q,omitempty"`
+
+	// Currency Converts the price of the rentals into the currency, an ISO 4217 code. With a currency `price_min`, `price_max` and the price sort use the converted prices too, and rentals priced in a currency without an exchange rate are left out. Without it every rental is priced in its own currency.
+	Currency *Currency `form:"currency,omitempty" json:"currency,omitempty"`
+}
+
+// SearchRentalsParams defines parameters for SearchRentals.
+type SearchRentalsParams struct {
+	// PriceMin The minimum price of the rental.
+	PriceMin *PriceMin `form:"price_min,omitempty" json:"price_min,omitempty"`
+
+	// PriceMax The maximum price of the rental.
+	PriceMax *PriceMax `form:"price_max,omitempty" json:"price_max,omitempty"`
+
+	// Limit The maximum number of rentals to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset The offset of the rentals to return.
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
 
-	// Sort The sort order of the rentals to return.
-	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+	// Cursor The opaque cursor from the X-Next-Cursor header of the previous page. Returns the rentals sorted after it, unaffected by rentals added or removed in between. Must be sent with the same filters and sort as the previous page and can not be combined with offset.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Ids The comma separated list of rental ids to return.
+	Ids *Ids `form:"ids,omitempty" json:"ids,omitempty"`
+
+	// Near The comma separated pair [lat,lng] to return rentals near.
+	Near *Near `form:"near,omitempty" json:"near,omitempty"`
+
+	// Radius The search radius around `near`, in `unit`. Defaults to 100.
+	Radius *Radius `form:"radius,omitempty" json:"radius,omitempty"`
+
+	// Unit The unit of `radius` and of the returned rental distances. Defaults to miles.
+	Unit *Unit `form:"unit,omitempty" json:"unit,omitempty"`
+
+	// Bbox The comma separated map viewport [minLng,minLat,maxLng,maxLat] to return rentals within. A minLng greater than maxLng selects a box crossing the antimeridian.
+	Bbox *BBox `form:"bbox,omitempty" json:"bbox,omitempty"`
+
+	// StartDate Only returns rentals that can be booked from this day, the check-in day. Requires `end_date`.
+	StartDate *StartDate `form:"start_date,omitempty" json:"start_date,omitempty"`
+
+	// EndDate Only returns rentals that can be booked until this day, the check-out day, which stays free for the next booking. Requires `start_date`.
+	EndDate *EndDate `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// Type The comma separated list of rental types to return rentals of any of.
+	Type *Type `form:"type,omitempty" json:"type,omitempty"`
+
+	// Make Only returns rentals of the vehicle make, regardless of case.
+	Make *Make `form:"make,omitempty" json:"make,omitempty"`
+
+	// Model Only returns rentals of the vehicle model, regardless of case.
+	Model *Model `form:"model,omitempty" json:"model,omitempty"`
+
+	// YearMin The minimum vehicle year of the rental.
+	YearMin *YearMin `form:"year_min,omitempty" json:"year_min,omitempty"`
+
+	// YearMax The maximum vehicle year of the rental.
+	YearMax *YearMax `form:"year_max,omitempty" json:"year_max,omitempty"`
+
+	// SleepsMin The minimum number of people the rental sleeps.
+	SleepsMin *SleepsMin `form:"sleeps_min,omitempty" json:"sleeps_min,omitempty"`
+
+	// LengthMin The minimum vehicle length of the rental, in feet.
+	LengthMin *LengthMin `form:"length_min,omitempty" json:"length_min,omitempty"`
+
+	// LengthMax The maximum vehicle length of the rental, in feet.
+	LengthMax *LengthMax `form:"length_max,omitempty" json:"length_max,omitempty"`
+
+	// Q Only returns rentals whose name, description, make or model match all words of the full-text search, regardless of case and word form. Quoted phrases and `or` are supported. Makes and models also match with small typos and by their known aliases, like `VW` for `Volkswagen`.
+	Q *Query `form:"q,omitempty" json:"q,omitempty"`
+
+	// Sort The comma separated list of fields to sort the rentals by. A leading `-` sorts the field in descending order. Sorting by `distance` requires `near`. Sorting by `relevance` requires `q` and puts the best matches first, `-relevance` reverses it. Ties are broken by the rental id.
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Currency Converts the price of the rentals into the currency, an ISO 4217 code. With a currency `price_min`, `price_max` and the price sort use the converted prices too, and rentals priced in a currency without an exchange rate are left out. Without it every rental is priced in its own currency.
+	Currency *Currency `form:"currency,omitempty" json:"currency,omitempty"`
+}
+
+// SearchRentalsParamsSort defines parameters for SearchRentals.
+type SearchRentalsParamsSort string
+
+// GetRentalParams defines parameters for GetRental.
+type GetRentalParams struct {
+	// Currency Converts the price of the rentals into the currency, an ISO 4217 code. With a currency `price_min`, `price_max` and the price sort use the converted prices too, and rentals priced in a currency without an exchange rate are left out. Without it every rental is priced in its own currency.
+	Currency *Currency `form:"currency,omitempty" json:"currency,omitempty"`
+}
+
+// GetRentalAvailabilityParams defines parameters for GetRentalAvailability.
+type GetRentalAvailabilityParams struct {
+	// From The first day of the range, today when missing.
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To The day after the last day of the range, 30 days after `from` when missing. The range can be at most 366 days long.
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetRentalQuoteParams defines parameters for GetRentalQuote.
+type GetRentalQuoteParams struct {
+	// Start The check-in day.
+	Start openapi_types.Date `form:"start" json:"start"`
+
+	// End The check-out day. The stay must be at least as long as the minimum number of nights of the rental, and at most 366 days long.
+	End openapi_types.Date `form:"end" json:"end"`
+
+	// Guests The number of guests, 1 when missing.
+	Guests *int `form:"guests,omitempty" json:"guests,omitempty"`
+}
+
+// GetUserRentalsParams defines parameters for GetUserRentals.
+type GetUserRentalsParams struct {
+	// PriceMin The minimum price of the rental.
+	PriceMin *PriceMin `form:"price_min,omitempty" json:"price_min,omitempty"`
+
+	// PriceMax The maximum price of the rental.
+	PriceMax *PriceMax `form:"price_max,omitempty" json:"price_max,omitempty"`
+
+	// Limit The maximum number of rentals to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset The offset of the rentals to return.
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor The opaque cursor from the X-Next-Cursor header of the previous page. Returns the rentals sorted after it, unaffected by rentals added or removed in between. Must be sent with the same filters and sort as the previous page and can not be combined with offset.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Ids The comma separated list of rental ids to return.
+	Ids *Ids `form:"ids,omitempty" json:"ids,omitempty"`
+
+	// Near The comma separated pair [lat,lng] to return rentals near.
+	Near *Near `form:"near,omitempty" json:"near,omitempty"`
+
+	// Radius The search radius around `near`, in `unit`. Defaults to 100.
+	Radius *Radius `form:"radius,omitempty" json:"radius,omitempty"`
+
+	// Unit The unit of `radius` and of the returned rental distances. Defaults to miles.
+	Unit *Unit `form:"unit,omitempty" json:"unit,omitempty"`
+
+	// Bbox The comma separated map viewport [minLng,minLat,maxLng,maxLat] to return rentals within. A minLng greater than maxLng selects a box crossing the antimeridian.
+	Bbox *BBox `form:"bbox,omitempty" json:"bbox,omitempty"`
+
+	// StartDate Only returns rentals that can be booked from this day, the check-in day. Requires `end_date`.
+	StartDate *StartDate `form:"start_date,omitempty" json:"start_date,omitempty"`
+
+	// EndDate Only returns rentals that can be booked until this day, the check-out day, which stays free for the next booking. Requires `start_date`.
+	EndDate *EndDate `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// Type The comma separated list of rental types to return rentals of any of.
+	Type *Type `form:"type,omitempty" json:"type,omitempty"`
+
+	// Make Only returns rentals of the vehicle make, regardless of case.
+	Make *Make `form:"make,omitempty" json:"make,omitempty"`
+
+	// Model Only returns rentals of the vehicle model, regardless of case.
+	Model *Model `form:"model,omitempty" json:"model,omitempty"`
+
+	// YearMin The minimum vehicle year of the rental.
+	YearMin *YearMin `form:"year_min,omitempty" json:"year_min,omitempty"`
+
+	// YearMax The maximum vehicle year of the rental.
+	YearMax *YearMax `form:"year_max,omitempty" json:"year_max,omitempty"`
+
+	// SleepsMin The minimum number of people the rental sleeps.
+	SleepsMin *SleepsMin `form:"sleeps_min,omitempty" json:"sleeps_min,omitempty"`
+
+	// LengthMin The minimum vehicle length of the rental, in feet.
+	LengthMin *LengthMin `form:"length_min,omitempty" json:"length_min,omitempty"`
+
+	// LengthMax The maximum vehicle length of the rental, in feet.
+	LengthMax *LengthMax `form:"length_max,omitempty" json:"length_max,omitempty"`
+
+	// Q Only returns rentals whose name, description, make or model match all words of the full-text search, regardless of case and word form. Quoted phrases and `or` are supported. Makes and models also match with small typos and by their known aliases, like `VW` for `Volkswagen`.
+	Q *Query `form:"q,omitempty" json:"q,omitempty"`
+
+	// Sort The comma separated list of fields to sort the rentals by. A leading `-` sorts the field in descending order. Sorting by `distance` requires `near`. Sorting by `relevance` requires `q` and puts the best matches first, `-relevance` reverses it. Ties are broken by the rental id.
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Currency Converts the price of the rentals into the currency, an ISO 4217 code. With a currency `price_min`, `price_max` and the price sort use the converted prices too, and rentals priced in a currency without an exchange rate are left out. Without it every rental is priced in its own currency.
+	Currency *Currency `form:"currency,omitempty" json:"currency,omitempty"`
+}
+
+// GetUserRentalsParamsSort defines parameters for GetUserRentals.
+type GetUserRentalsParamsSort string
+
+// UpdateBookingStatusJSONRequestBody defines body for UpdateBookingStatus for application/json ContentType.
+type UpdateBookingStatusJSONRequestBody = BookingStatusUpdate
+
+// CreateRentalJSONRequestBody defines body for CreateRental for application/json ContentType.
+type CreateRentalJSONRequestBody = RentalInput
+
+// SearchRentalsJSONRequestBody defines body for SearchRentals for application/json ContentType.
+type SearchRentalsJSONRequestBody = RentalsSearch
+
+// PatchRentalJSONRequestBody defines body for PatchRental for application/json ContentType.
+type PatchRentalJSONRequestBody = RentalPatch
+
+// UpdateRentalJSONRequestBody defines body for UpdateRental for application/json ContentType.
+type UpdateRentalJSONRequestBody = RentalInput
+
+// CreateBookingJSONRequestBody defines body for CreateBooking for application/json ContentType.
+type CreateBookingJSONRequestBody = BookingInput
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /v1/bookings/{booking_id})
+	GetBooking(ctx echo.Context, bookingId BookingId) error
+
+	// (PATCH /v1/bookings/{booking_id})
+	UpdateBookingStatus(ctx echo.Context, bookingId BookingId) error
+
+	// (GET /v1/rentals)
+	GetRentals(ctx echo.Context, params GetRentalsParams) error
+
+	// (POST /v1/rentals)
+	CreateRental(ctx echo.Context) error
+
+	// (GET /v1/rentals/facets)
+	GetRentalFacets(ctx echo.Context, params GetRentalFacetsParams) error
+
+	// (POST /v1/rentals/search)
+	SearchRentals(ctx echo.Context, params SearchRentalsParams) error
+
+	// (DELETE /v1/rentals/{rental_id})
+	DeleteRental(ctx echo.Context, rentalId RentalId) error
+
+	// (GET /v1/rentals/{rental_id})
+	GetRental(ctx echo.Context, rentalId RentalId, params GetRentalParams) error
+
+	// (PATCH /v1/rentals/{rental_id})
+	PatchRental(ctx echo.Context, rentalId RentalId) error
+
+	// (PUT /v1/rentals/{rental_id})
+	UpdateRental(ctx echo.Context, rentalId RentalId) error
+
+	// (GET /v1/rentals/{rental_id}/availability)
+	GetRentalAvailability(ctx echo.Context, rentalId RentalId, params GetRentalAvailabilityParams) error
+
+	// (POST /v1/rentals/{rental_id}/bookings)
+	CreateBooking(ctx echo.Context, rentalId RentalId) error
+
+	// (GET /v1/rentals/{rental_id}/quote)
+	GetRentalQuote(ctx echo.Context, rentalId RentalId, params GetRentalQuoteParams) error
+
+	// (GET /v1/users/{user_id})
+	GetUser(ctx echo.Context, userId UserId) error
+
+	// (GET /v1/users/{user_id}/rentals)
+	GetUserRentals(ctx echo.Context, userId UserId, params GetUserRentalsParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetBooking converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBooking(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId BookingId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "booking_id", runtime.ParamLocationPath, ctx.Param("booking_id"), &bookingId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter booking_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetBooking(ctx, bookingId)
+	return err
+}
+
+// UpdateBookingStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateBookingStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId BookingId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "booking_id", runtime.ParamLocationPath, ctx.Param("booking_id"), &bookingId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter booking_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateBookingStatus(ctx, bookingId)
+	return err
+}
+
+// GetRentals converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRentals(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRentalsParams
+	// ------------- Optional query parameter "price_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "price_min", ctx.QueryParams(), &params.PriceMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter price_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "price_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "price_max", ctx.QueryParams(), &params.PriceMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter price_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "ids" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "ids", ctx.QueryParams(), &params.Ids)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ids: %s", err))
+	}
+
+	// ------------- Optional query parameter "near" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "near", ctx.QueryParams(), &params.Near)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter near: %s", err))
+	}
+
+	// ------------- Optional query parameter "radius" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "radius", ctx.QueryParams(), &params.Radius)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter radius: %s", err))
+	}
+
+	// ------------- Optional query parameter "unit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "unit", ctx.QueryParams(), &params.Unit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter unit: %s", err))
+	}
+
+	// ------------- Optional query parameter "bbox" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "bbox", ctx.QueryParams(), &params.Bbox)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bbox: %s", err))
+	}
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_date", ctx.QueryParams(), &params.StartDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter start_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", ctx.QueryParams(), &params.EndDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter end_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "type", ctx.QueryParams(), &params.Type)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter type: %s", err))
+	}
+
+	// ------------- Optional query parameter "make" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "make", ctx.QueryParams(), &params.Make)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter make: %s", err))
+	}
+
+	// ------------- Optional query parameter "model" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "model", ctx.QueryParams(), &params.Model)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter model: %s", err))
+	}
+
+	// ------------- Optional query parameter "year_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_min", ctx.QueryParams(), &params.YearMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter year_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "year_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_max", ctx.QueryParams(), &params.YearMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter year_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "sleeps_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sleeps_min", ctx.QueryParams(), &params.SleepsMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sleeps_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "length_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "length_min", ctx.QueryParams(), &params.LengthMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter length_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "length_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "length_max", ctx.QueryParams(), &params.LengthMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter length_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", ctx.QueryParams(), &params.Q)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter q: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", ctx.QueryParams(), &params.Currency)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetRentals(ctx, params)
+	return err
+}
+
+// CreateRental converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateRental(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateRental(ctx)
+	return err
+}
+
+// GetRentalFacets converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRentalFacets(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRentalFacetsParams
+	// ------------- Optional query parameter "price_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "price_min", ctx.QueryParams(), &params.PriceMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter price_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "price_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "price_max", ctx.QueryParams(), &params.PriceMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter price_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "ids" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "ids", ctx.QueryParams(), &params.Ids)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ids: %s", err))
+	}
+
+	// ------------- Optional query parameter "near" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "near", ctx.QueryParams(), &params.Near)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter near: %s", err))
+	}
+
+	// ------------- Optional query parameter "radius" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "radius", ctx.QueryParams(), &params.Radius)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter radius: %s", err))
+	}
+
+	// ------------- Optional query parameter "unit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "unit", ctx.QueryParams(), &params.Unit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter unit: %s", err))
+	}
+
+	// ------------- Optional query parameter "bbox" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "bbox", ctx.QueryParams(), &params.Bbox)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bbox: %s", err))
+	}
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_date", ctx.QueryParams(), &params.StartDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter start_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", ctx.QueryParams(), &params.EndDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter end_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "type", ctx.QueryParams(), &params.Type)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter type: %s", err))
+	}
+
+	// ------------- Optional query parameter "make" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "make", ctx.QueryParams(), &params.Make)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter make: %s", err))
+	}
+
+	// ------------- Optional query parameter "model" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "model", ctx.QueryParams(), &params.Model)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter model: %s", err))
+	}
+
+	// ------------- Optional query parameter "year_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_min", ctx.QueryParams(), &params.YearMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter year_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "year_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_max", ctx.QueryParams(), &params.YearMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter year_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "sleeps_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sleeps_min", ctx.QueryParams(), &params.SleepsMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sleeps_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "length_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "length_min", ctx.QueryParams(), &params.LengthMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter length_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "length_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "length_max", ctx.QueryParams(), &params.LengthMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter length_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", ctx.QueryParams(), &params.Q)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter q: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", ctx.QueryParams(), &params.Currency)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetRentalFacets(ctx, params)
+	return err
+}
+
+// SearchRentals converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchRentals(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchRentalsParams
+	// ------------- Optional query parameter "price_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "price_min", ctx.QueryParams(), &params.PriceMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter price_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "price_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "price_max", ctx.QueryParams(), &params.PriceMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter price_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "ids" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "ids", ctx.QueryParams(), &params.Ids)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ids: %s", err))
+	}
+
+	// ------------- Optional query parameter "near" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "near", ctx.QueryParams(), &params.Near)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter near: %s", err))
+	}
+
+	// ------------- Optional query parameter "radius" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "radius", ctx.QueryParams(), &params.Radius)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter radius: %s", err))
+	}
+
+	// ------------- Optional query parameter "unit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "unit", ctx.QueryParams(), &params.Unit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter unit: %s", err))
+	}
+
+	// ------------- Optional query parameter "bbox" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "bbox", ctx.QueryParams(), &params.Bbox)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bbox: %s", err))
+	}
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_date", ctx.QueryParams(), &params.StartDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter start_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", ctx.QueryParams(), &params.EndDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter end_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "type", ctx.QueryParams(), &params.Type)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter type: %s", err))
+	}
+
+	// ------------- Optional query parameter "make" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "make", ctx.QueryParams(), &params.Make)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter make: %s", err))
+	}
+
+	// ------------- Optional query parameter "model" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "model", ctx.QueryParams(), &params.Model)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter model: %s", err))
+	}
+
+	// ------------- Optional query parameter "year_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_min", ctx.QueryParams(), &params.YearMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter year_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "year_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_max", ctx.QueryParams(), &params.YearMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter year_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "sleeps_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sleeps_min", ctx.QueryParams(), &params.SleepsMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sleeps_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "length_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "length_min", ctx.QueryParams(), &params.LengthMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter length_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "length_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "length_max", ctx.QueryParams(), &params.LengthMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter length_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", ctx.QueryParams(), &params.Q)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter q: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", ctx.QueryParams(), &params.Currency)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SearchRentals(ctx, params)
+	return err
+}
+
+// DeleteRental converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteRental(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rental_id" -------------
+	var rentalId RentalId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "rental_id", runtime.ParamLocationPath, ctx.Param("rental_id"), &rentalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rental_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteRental(ctx, rentalId)
+	return err
+}
+
+// GetRental converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRental(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rental_id" -------------
+	var rentalId RentalId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "rental_id", runtime.ParamLocationPath, ctx.Param("rental_id"), &rentalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rental_id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRentalParams
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", ctx.QueryParams(), &params.Currency)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetRental(ctx, rentalId, params)
+	return err
+}
+
+// PatchRental converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchRental(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rental_id" -------------
+	var rentalId RentalId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "rental_id", runtime.ParamLocationPath, ctx.Param("rental_id"), &rentalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rental_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchRental(ctx, rentalId)
+	return err
+}
+
+// UpdateRental converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateRental(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rental_id" -------------
+	var rentalId RentalId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "rental_id", runtime.ParamLocationPath, ctx.Param("rental_id"), &rentalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rental_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateRental(ctx, rentalId)
+	return err
+}
+
+// GetRentalAvailability converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRentalAvailability(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rental_id" -------------
+	var rentalId RentalId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "rental_id", runtime.ParamLocationPath, ctx.Param("rental_id"), &rentalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rental_id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRentalAvailabilityParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetRentalAvailability(ctx, rentalId, params)
+	return err
+}
+
+// CreateBooking converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateBooking(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rental_id" -------------
+	var rentalId RentalId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "rental_id", runtime.ParamLocationPath, ctx.Param("rental_id"), &rentalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rental_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateBooking(ctx, rentalId)
+	return err
+}
+
+// GetRentalQuote converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRentalQuote(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rental_id" -------------
+	var rentalId RentalId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "rental_id", runtime.ParamLocationPath, ctx.Param("rental_id"), &rentalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rental_id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRentalQuoteParams
+	// ------------- Required query parameter "start" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "start", ctx.QueryParams(), &params.Start)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter start: %s", err))
+	}
+
+	// ------------- Required query parameter "end" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "end", ctx.QueryParams(), &params.End)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter end: %s", err))
+	}
+
+	// ------------- Optional query parameter "guests" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "guests", ctx.QueryParams(), &params.Guests)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter guests: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetRentalQuote(ctx, rentalId, params)
+	return err
+}
+
+// GetUser converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUser(ctx, userId)
+	return err
+}
+
+// GetUserRentals converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUserRentals(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserRentalsParams
+	// ------------- Optional query parameter "price_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "price_min", ctx.QueryParams(), &params.PriceMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter price_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "price_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "price_max", ctx.QueryParams(), &params.PriceMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter price_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "ids" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "ids", ctx.QueryParams(), &params.Ids)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ids: %s", err))
+	}
+
+	// ------------- Optional query parameter "near" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "near", ctx.QueryParams(), &params.Near)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter near: %s", err))
+	}
+
+	// ------------- Optional query parameter "radius" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "radius", ctx.QueryParams(), &params.Radius)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter radius: %s", err))
+	}
+
+	// ------------- Optional query parameter "unit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "unit", ctx.QueryParams(), &params.Unit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter unit: %s", err))
+	}
+
+	// ------------- Optional query parameter "bbox" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "bbox", ctx.QueryParams(), &params.Bbox)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bbox: %s", err))
+	}
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_date", ctx.QueryParams(), &params.StartDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter start_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", ctx.QueryParams(), &params.EndDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter end_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "type", ctx.QueryParams(), &params.Type)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter type: %s", err))
+	}
+
+	// ------------- Optional query parameter "make" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "make", ctx.QueryParams(), &params.Make)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter make: %s", err))
+	}
+
+	// ------------- Optional query parameter "model" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "model", ctx.QueryParams(), &params.Model)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter model: %s", err))
+	}
+
+	// ------------- Optional query parameter "year_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_min", ctx.QueryParams(), &params.YearMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter year_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "year_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year_max", ctx.QueryParams(), &params.YearMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter year_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "sleeps_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sleeps_min", ctx.QueryParams(), &params.SleepsMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sleeps_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "length_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "length_min", ctx.QueryParams(), &params.LengthMin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter length_min: %s", err))
+	}
+
+	// ------------- Optional query parameter "length_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "length_max", ctx.QueryParams(), &params.LengthMax)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter length_max: %s", err))
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", ctx.QueryParams(), &params.Q)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter q: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", ctx.QueryParams(), &params.Currency)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUserRentals(ctx, userId, params)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/v1/bookings/:booking_id", wrapper.GetBooking)
+	router.PATCH(baseURL+"/v1/bookings/:booking_id", wrapper.UpdateBookingStatus)
+	router.GET(baseURL+"/v1/rentals", wrapper.GetRentals)
+	router.POST(baseURL+"/v1/rentals", wrapper.CreateRental)
+	router.GET(baseURL+"/v1/rentals/facets", wrapper.GetRentalFacets)
+	router.POST(baseURL+"/v1/rentals/search", wrapper.SearchRentals)
+	router.DELETE(baseURL+"/v1/rentals/:rental_id", wrapper.DeleteRental)
+	router.GET(baseURL+"/v1/rentals/:rental_id", wrapper.GetRental)
+	router.PATCH(baseURL+"/v1/rentals/:rental_id", wrapper.PatchRental)
+	router.PUT(baseURL+"/v1/rentals/:rental_id", wrapper.UpdateRental)
+	router.GET(baseURL+"/v1/rentals/:rental_id/availability", wrapper.GetRentalAvailability)
+	router.POST(baseURL+"/v1/rentals/:rental_id/bookings", wrapper.CreateBooking)
+	router.GET(baseURL+"/v1/rentals/:rental_id/quote", wrapper.GetRentalQuote)
+	router.GET(baseURL+"/v1/users/:user_id", wrapper.GetUser)
+	router.GET(baseURL+"/v1/users/:user_id/rentals", wrapper.GetUserRentals)
+
+}
+
+type ProblemApplicationProblemPlusJSONResponse Problem
+
+type GetBookingRequestObject struct {
+	BookingId BookingId `json:"booking_id"`
+}
+
+type GetBookingResponseObject interface {
+	VisitGetBookingResponse(w http.ResponseWriter) error
+}
+
+type GetBooking200JSONResponse Booking
+
+func (response GetBooking200JSONResponse) VisitGetBookingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBooking400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetBooking400ApplicationProblemPlusJSONResponse) VisitGetBookingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBooking404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetBooking404ApplicationProblemPlusJSONResponse) VisitGetBookingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBooking500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetBooking500ApplicationProblemPlusJSONResponse) VisitGetBookingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBookingdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetBookingdefaultApplicationProblemPlusJSONResponse) VisitGetBookingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateBookingStatusRequestObject struct {
+	BookingId BookingId `json:"booking_id"`
+	Body      *UpdateBookingStatusJSONRequestBody
+}
+
+type UpdateBookingStatusResponseObject interface {
+	VisitUpdateBookingStatusResponse(w http.ResponseWriter) error
+}
+
+type UpdateBookingStatus200JSONResponse Booking
+
+func (response UpdateBookingStatus200JSONResponse) VisitUpdateBookingStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateBookingStatus400ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateBookingStatus400ApplicationProblemPlusJSONResponse) VisitUpdateBookingStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateBookingStatus404ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateBookingStatus404ApplicationProblemPlusJSONResponse) VisitUpdateBookingStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateBookingStatus409ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateBookingStatus409ApplicationProblemPlusJSONResponse) VisitUpdateBookingStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateBookingStatus500ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateBookingStatus500ApplicationProblemPlusJSONResponse) VisitUpdateBookingStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateBookingStatusdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response UpdateBookingStatusdefaultApplicationProblemPlusJSONResponse) VisitUpdateBookingStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetRentalsRequestObject struct {
+	Params GetRentalsParams
+}
+
+type GetRentalsResponseObject interface {
+	VisitGetRentalsResponse(w http.ResponseWriter) error
+}
+
+type GetRentals200ResponseHeaders struct {
+	Link        string
+	XNextCursor string
+	XTotalCount int
+}
+
+type GetRentals200JSONResponse struct {
+	Body    []Rental
+	Headers GetRentals200ResponseHeaders
+}
+
+func (response GetRentals200JSONResponse) VisitGetRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetRentals400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRentals400ApplicationProblemPlusJSONResponse) VisitGetRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentals500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRentals500ApplicationProblemPlusJSONResponse) VisitGetRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetRentalsdefaultApplicationProblemPlusJSONResponse) VisitGetRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateRentalRequestObject struct {
+	Body *CreateRentalJSONRequestBody
+}
+
+type CreateRentalResponseObject interface {
+	VisitCreateRentalResponse(w http.ResponseWriter) error
+}
+
+type CreateRental201ResponseHeaders struct {
+	Location string
+}
+
+type CreateRental201JSONResponse struct {
+	Body    Rental
+	Headers CreateRental201ResponseHeaders
+}
+
+func (response CreateRental201JSONResponse) VisitCreateRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateRental400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateRental400ApplicationProblemPlusJSONResponse) VisitCreateRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRental500ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateRental500ApplicationProblemPlusJSONResponse) VisitCreateRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRentaldefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateRentaldefaultApplicationProblemPlusJSONResponse) VisitCreateRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetRentalFacetsRequestObject struct {
+	Params GetRentalFacetsParams
+}
+
+type GetRentalFacetsResponseObject interface {
+	VisitGetRentalFacetsResponse(w http.ResponseWriter) error
+}
+
+type GetRentalFacets200JSONResponse RentalFacets
+
+func (response GetRentalFacets200JSONResponse) VisitGetRentalFacetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalFacets400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRentalFacets400ApplicationProblemPlusJSONResponse) VisitGetRentalFacetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalFacets500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRentalFacets500ApplicationProblemPlusJSONResponse) VisitGetRentalFacetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalFacetsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetRentalFacetsdefaultApplicationProblemPlusJSONResponse) VisitGetRentalFacetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SearchRentalsRequestObject struct {
+	Params SearchRentalsParams
+	Body   *SearchRentalsJSONRequestBody
+}
+
+type SearchRentalsResponseObject interface {
+	VisitSearchRentalsResponse(w http.ResponseWriter) error
+}
+
+type SearchRentals200ResponseHeaders struct {
+	Link        string
+	XNextCursor string
+	XTotalCount int
+}
+
+type SearchRentals200JSONResponse struct {
+	Body    []Rental
+	Headers SearchRentals200ResponseHeaders
+}
+
+func (response SearchRentals200JSONResponse) VisitSearchRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SearchRentals400ApplicationProblemPlusJSONResponse Problem
+
+func (response SearchRentals400ApplicationProblemPlusJSONResponse) VisitSearchRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchRentals500ApplicationProblemPlusJSONResponse Problem
+
+func (response SearchRentals500ApplicationProblemPlusJSONResponse) VisitSearchRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchRentals503ApplicationProblemPlusJSONResponse Problem
+
+func (response SearchRentals503ApplicationProblemPlusJSONResponse) VisitSearchRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchRentalsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response SearchRentalsdefaultApplicationProblemPlusJSONResponse) VisitSearchRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteRentalRequestObject struct {
+	RentalId RentalId `json:"rental_id"`
+}
+
+type DeleteRentalResponseObject interface {
+	VisitDeleteRentalResponse(w http.ResponseWriter) error
+}
+
+type DeleteRental204Response struct {
+}
+
+func (response DeleteRental204Response) VisitDeleteRentalResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteRental400ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteRental400ApplicationProblemPlusJSONResponse) VisitDeleteRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRental404ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteRental404ApplicationProblemPlusJSONResponse) VisitDeleteRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRental500ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteRental500ApplicationProblemPlusJSONResponse) VisitDeleteRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRentaldefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response DeleteRentaldefaultApplicationProblemPlusJSONResponse) VisitDeleteRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetRentalRequestObject struct {
+	RentalId RentalId `json:"rental_id"`
+	Params   GetRentalParams
+}
+
+type GetRentalResponseObject interface {
+	VisitGetRentalResponse(w http.ResponseWriter) error
+}
+
+type GetRental200JSONResponse Rental
+
+func (response GetRental200JSONResponse) VisitGetRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRental400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRental400ApplicationProblemPlusJSONResponse) VisitGetRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRental404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRental404ApplicationProblemPlusJSONResponse) VisitGetRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRental500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRental500ApplicationProblemPlusJSONResponse) VisitGetRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentaldefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetRentaldefaultApplicationProblemPlusJSONResponse) VisitGetRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchRentalRequestObject struct {
+	RentalId RentalId `json:"rental_id"`
+	Body     *PatchRentalJSONRequestBody
+}
+
+type PatchRentalResponseObject interface {
+	VisitPatchRentalResponse(w http.ResponseWriter) error
+}
+
+type PatchRental200JSONResponse Rental
+
+func (response PatchRental200JSONResponse) VisitPatchRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchRental400ApplicationProblemPlusJSONResponse Problem
+
+func (response PatchRental400ApplicationProblemPlusJSONResponse) VisitPatchRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchRental404ApplicationProblemPlusJSONResponse Problem
+
+func (response PatchRental404ApplicationProblemPlusJSONResponse) VisitPatchRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchRental500ApplicationProblemPlusJSONResponse Problem
+
+func (response PatchRental500ApplicationProblemPlusJSONResponse) VisitPatchRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchRentaldefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response PatchRentaldefaultApplicationProblemPlusJSONResponse) VisitPatchRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateRentalRequestObject struct {
+	RentalId RentalId `json:"rental_id"`
+	Body     *UpdateRentalJSONRequestBody
+}
+
+type UpdateRentalResponseObject interface {
+	VisitUpdateRentalResponse(w http.ResponseWriter) error
+}
+
+type UpdateRental200JSONResponse Rental
+
+func (response UpdateRental200JSONResponse) VisitUpdateRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateRental400ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateRental400ApplicationProblemPlusJSONResponse) VisitUpdateRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateRental404ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateRental404ApplicationProblemPlusJSONResponse) VisitUpdateRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateRental500ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateRental500ApplicationProblemPlusJSONResponse) VisitUpdateRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateRentaldefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response UpdateRentaldefaultApplicationProblemPlusJSONResponse) VisitUpdateRentalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetRentalAvailabilityRequestObject struct {
+	RentalId RentalId `json:"rental_id"`
+	Params   GetRentalAvailabilityParams
+}
+
+type GetRentalAvailabilityResponseObject interface {
+	VisitGetRentalAvailabilityResponse(w http.ResponseWriter) error
+}
+
+type GetRentalAvailability200JSONResponse Availability
+
+func (response GetRentalAvailability200JSONResponse) VisitGetRentalAvailabilityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalAvailability400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRentalAvailability400ApplicationProblemPlusJSONResponse) VisitGetRentalAvailabilityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalAvailability404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRentalAvailability404ApplicationProblemPlusJSONResponse) VisitGetRentalAvailabilityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalAvailability500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRentalAvailability500ApplicationProblemPlusJSONResponse) VisitGetRentalAvailabilityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalAvailabilitydefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetRentalAvailabilitydefaultApplicationProblemPlusJSONResponse) VisitGetRentalAvailabilityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateBookingRequestObject struct {
+	RentalId RentalId `json:"rental_id"`
+	Body     *CreateBookingJSONRequestBody
+}
+
+type CreateBookingResponseObject interface {
+	VisitCreateBookingResponse(w http.ResponseWriter) error
+}
+
+type CreateBooking201ResponseHeaders struct {
+	Location string
+}
+
+type CreateBooking201JSONResponse struct {
+	Body    Booking
+	Headers CreateBooking201ResponseHeaders
+}
+
+func (response CreateBooking201JSONResponse) VisitCreateBookingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateBooking400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateBooking400ApplicationProblemPlusJSONResponse) VisitCreateBookingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBooking404ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateBooking404ApplicationProblemPlusJSONResponse) VisitCreateBookingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBooking409ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateBooking409ApplicationProblemPlusJSONResponse) VisitCreateBookingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBooking500ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateBooking500ApplicationProblemPlusJSONResponse) VisitCreateBookingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBookingdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateBookingdefaultApplicationProblemPlusJSONResponse) VisitCreateBookingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetRentalQuoteRequestObject struct {
+	RentalId RentalId `json:"rental_id"`
+	Params   GetRentalQuoteParams
+}
+
+type GetRentalQuoteResponseObject interface {
+	VisitGetRentalQuoteResponse(w http.ResponseWriter) error
+}
+
+type GetRentalQuote200JSONResponse Quote
+
+func (response GetRentalQuote200JSONResponse) VisitGetRentalQuoteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalQuote400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRentalQuote400ApplicationProblemPlusJSONResponse) VisitGetRentalQuoteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalQuote404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRentalQuote404ApplicationProblemPlusJSONResponse) VisitGetRentalQuoteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalQuote500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRentalQuote500ApplicationProblemPlusJSONResponse) VisitGetRentalQuoteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRentalQuotedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetRentalQuotedefaultApplicationProblemPlusJSONResponse) VisitGetRentalQuoteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUserRequestObject struct {
+	UserId UserId `json:"user_id"`
+}
+
+type GetUserResponseObject interface {
+	VisitGetUserResponse(w http.ResponseWriter) error
+}
+
+type GetUser200JSONResponse User
+
+func (response GetUser200JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetUser400ApplicationProblemPlusJSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetUser404ApplicationProblemPlusJSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetUser500ApplicationProblemPlusJSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetUserdefaultApplicationProblemPlusJSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUserRentalsRequestObject struct {
+	UserId UserId `json:"user_id"`
+	Params GetUserRentalsParams
+}
+
+type GetUserRentalsResponseObject interface {
+	VisitGetUserRentalsResponse(w http.ResponseWriter) error
+}
+
+type GetUserRentals200ResponseHeaders struct {
+	Link        string
+	XNextCursor string
+	XTotalCount int
+}
+
+type GetUserRentals200JSONResponse struct {
+	Body    []Rental
+	Headers GetUserRentals200ResponseHeaders
+}
+
+func (response GetUserRentals200JSONResponse) VisitGetUserRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUserRentals400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetUserRentals400ApplicationProblemPlusJSONResponse) VisitGetUserRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRentals404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetUserRentals404ApplicationProblemPlusJSONResponse) VisitGetUserRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRentals500ApplicationProblemPlusJSONResponse Problem
+
+func (response GetUserRentals500ApplicationProblemPlusJSONResponse) VisitGetUserRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRentalsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetUserRentalsdefaultApplicationProblemPlusJSONResponse) VisitGetUserRentalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (GET /v1/bookings/{booking_id})
+	GetBooking(ctx context.Context, request GetBookingRequestObject) (GetBookingResponseObject, error)
+
+	// (PATCH /v1/bookings/{booking_id})
+	UpdateBookingStatus(ctx context.Context, request UpdateBookingStatusRequestObject) (UpdateBookingStatusResponseObject, error)
+
+	// (GET /v1/rentals)
+	GetRentals(ctx context.Context, request GetRentalsRequestObject) (GetRentalsResponseObject, error)
+
+	// (POST /v1/rentals)
+	CreateRental(ctx context.Context, request CreateRentalRequestObject) (CreateRentalResponseObject, error)
+
+	// (GET /v1/rentals/facets)
+	GetRentalFacets(ctx context.Context, request GetRentalFacetsRequestObject) (GetRentalFacetsResponseObject, error)
+
+	// (POST /v1/rentals/search)
+	SearchRentals(ctx context.Context, request SearchRentalsRequestObject) (SearchRentalsResponseObject, error)
+
+	// (DELETE /v1/rentals/{rental_id})
+	DeleteRental(ctx context.Context, request DeleteRentalRequestObject) (DeleteRentalResponseObject, error)
+
+	// (GET /v1/rentals/{rental_id})
+	GetRental(ctx context.Context, request GetRentalRequestObject) (GetRentalResponseObject, error)
+
+	// (PATCH /v1/rentals/{rental_id})
+	PatchRental(ctx context.Context, request PatchRentalRequestObject) (PatchRentalResponseObject, error)
+
+	// (PUT /v1/rentals/{rental_id})
+	UpdateRental(ctx context.Context, request UpdateRentalRequestObject) (UpdateRentalResponseObject, error)
+
+	// (GET /v1/rentals/{rental_id}/availability)
+	GetRentalAvailability(ctx context.Context, request GetRentalAvailabilityRequestObject) (GetRentalAvailabilityResponseObject, error)
+
+	// (POST /v1/rentals/{rental_id}/bookings)
+	CreateBooking(ctx context.Context, request CreateBookingRequestObject) (CreateBookingResponseObject, error)
+
+	// (GET /v1/rentals/{rental_id}/quote)
+	GetRentalQuote(ctx context.Context, request GetRentalQuoteRequestObject) (GetRentalQuoteResponseObject, error)
+
+	// (GET /v1/users/{user_id})
+	GetUser(ctx context.Context, request GetUserRequestObject) (GetUserResponseObject, error)
+
+	// (GET /v1/users/{user_id}/rentals)
+	GetUserRentals(ctx context.Context, request GetUserRentalsRequestObject) (GetUserRentalsResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetBooking operation middleware
+func (sh *strictHandler) GetBooking(ctx echo.Context, bookingId BookingId) error {
+	var request GetBookingRequestObject
+
+	request.BookingId = bookingId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBooking(ctx.Request().Context(), request.(GetBookingRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBooking")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetBookingResponseObject); ok {
+		return validResponse.VisitGetBookingResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateBookingStatus operation middleware
+func (sh *strictHandler) UpdateBookingStatus(ctx echo.Context, bookingId BookingId) error {
+	var request UpdateBookingStatusRequestObject
+
+	request.BookingId = bookingId
+
+	var body UpdateBookingStatusJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateBookingStatus(ctx.Request().Context(), request.(UpdateBookingStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateBookingStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UpdateBookingStatusResponseObject); ok {
+		return validResponse.VisitUpdateBookingStatusResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetRentals operation middleware
+func (sh *strictHandler) GetRentals(ctx echo.Context, params GetRentalsParams) error {
+	var request GetRentalsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRentals(ctx.Request().Context(), request.(GetRentalsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRentals")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetRentalsResponseObject); ok {
+		return validResponse.VisitGetRentalsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateRental operation middleware
+func (sh *strictHandler) CreateRental(ctx echo.Context) error {
+	var request CreateRentalRequestObject
+
+	var body CreateRentalJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateRental(ctx.Request().Context(), request.(CreateRentalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateRental")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateRentalResponseObject); ok {
+		return validResponse.VisitCreateRentalResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetRentalFacets operation middleware
+func (sh *strictHandler) GetRentalFacets(ctx echo.Context, params GetRentalFacetsParams) error {
+	var request GetRentalFacetsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRentalFacets(ctx.Request().Context(), request.(GetRentalFacetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRentalFacets")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetRentalFacetsResponseObject); ok {
+		return validResponse.VisitGetRentalFacetsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// SearchRentals operation middleware
+func (sh *strictHandler) SearchRentals(ctx echo.Context, params SearchRentalsParams) error {
+	var request SearchRentalsRequestObject
+
+	request.Params = params
+
+	var body SearchRentalsJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchRentals(ctx.Request().Context(), request.(SearchRentalsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchRentals")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(SearchRentalsResponseObject); ok {
+		return validResponse.VisitSearchRentalsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteRental operation middleware
+func (sh *strictHandler) DeleteRental(ctx echo.Context, rentalId RentalId) error {
+	var request DeleteRentalRequestObject
+
+	request.RentalId = rentalId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteRental(ctx.Request().Context(), request.(DeleteRentalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteRental")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteRentalResponseObject); ok {
+		return validResponse.VisitDeleteRentalResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetRental operation middleware
+func (sh *strictHandler) GetRental(ctx echo.Context, rentalId RentalId, params GetRentalParams) error {
+	var request GetRentalRequestObject
+
+	request.RentalId = rentalId
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRental(ctx.Request().Context(), request.(GetRentalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRental")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetRentalResponseObject); ok {
+		return validResponse.VisitGetRentalResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PatchRental operation middleware
+func (sh *strictHandler) PatchRental(ctx echo.Context, rentalId RentalId) error {
+	var request PatchRentalRequestObject
+
+	request.RentalId = rentalId
+
+	var body PatchRentalJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchRental(ctx.Request().Context(), request.(PatchRentalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchRental")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PatchRentalResponseObject); ok {
+		return validResponse.VisitPatchRentalResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateRental operation middleware
+func (sh *strictHandler) UpdateRental(ctx echo.Context, rentalId RentalId) error {
+	var request UpdateRentalRequestObject
+
+	request.RentalId = rentalId
+
+	var body UpdateRentalJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateRental(ctx.Request().Context(), request.(UpdateRentalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateRental")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UpdateRentalResponseObject); ok {
+		return validResponse.VisitUpdateRentalResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetRentalAvailability operation middleware
+func (sh *strictHandler) GetRentalAvailability(ctx echo.Context, rentalId RentalId, params GetRentalAvailabilityParams) error {
+	var request GetRentalAvailabilityRequestObject
+
+	request.RentalId = rentalId
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRentalAvailability(ctx.Request().Context(), request.(GetRentalAvailabilityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRentalAvailability")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetRentalAvailabilityResponseObject); ok {
+		return validResponse.VisitGetRentalAvailabilityResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateBooking operation middleware
+func (sh *strictHandler) CreateBooking(ctx echo.Context, rentalId RentalId) error {
+	var request CreateBookingRequestObject
+
+	request.RentalId = rentalId
+
+	var body CreateBookingJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateBooking(ctx.Request().Context(), request.(CreateBookingRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateBooking")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateBookingResponseObject); ok {
+		return validResponse.VisitCreateBookingResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetRentalQuote operation middleware
+func (sh *strictHandler) GetRentalQuote(ctx echo.Context, rentalId RentalId, params GetRentalQuoteParams) error {
+	var request GetRentalQuoteRequestObject
+
+	request.RentalId = rentalId
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRentalQuote(ctx.Request().Context(), request.(GetRentalQuoteRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRentalQuote")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetRentalQuoteResponseObject); ok {
+		return validResponse.VisitGetRentalQuoteResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetUser operation middleware
+func (sh *strictHandler) GetUser(ctx echo.Context, userId UserId) error {
+	var request GetUserRequestObject
+
+	request.UserId = userId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUser(ctx.Request().Context(), request.(GetUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUser")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetUserResponseObject); ok {
+		return validResponse.VisitGetUserResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetUserRentals operation middleware
+func (sh *strictHandler) GetUserRentals(ctx echo.Context, userId UserId, params GetUserRentalsParams) error {
+	var request GetUserRentalsRequestObject
+
+	request.UserId = userId
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserRentals(ctx.Request().Context(), request.(GetUserRentalsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserRentals")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetUserRentalsResponseObject); ok {
+		return validResponse.VisitGetUserRentalsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xYTW/jNhD9KwTbo1aWYidBfEvb3TZFiwbeZC9BENDSyOZWIrXkaBNv4P9ekJRky6Jl",
-	"pR9ATzGk+Xgz8/iGyitNZFFKAQI1nb9SnayhYPbne6WkMj9S0IniJXIp6JzerYGkkLEqRwLGhCjASglI",
-	"aUBLJUtQyEE7R2Q818di2JeELWWFBNfgooU0oPDCijIHa8g1UfClAo3kmWmyZCYNbkrzVqPiYkW3AdXI",
-	"sDqS6Je7u1viDEgiU2jxdlLNoqiNywXCCpQJjBzN28O4tjfEvuwC/oGlZOHw9nFuA2pq4QpSOn9oQDdJ",
-	"grZfj62nXH6GBA2Q32TCXHJfjQoEspzktVHYG0XCcTPoagy6pXxkgnxQTCRcJ9LX9ERWAtWJsM6mG/n+",
-	"47UvXs5wuDqGHKu02/HpZXh5ObsKaCZVYQLQVFZL2846gaiKpRtmLlYn2idW/Qzx2Vk4i69mo1KYmcJg",
-	"EmvR7ceP3nZ84+VgoG+87IS5msXR9BTnLA8amC7HbpBuBK5PPg7eKp4MF1caiz77UrY57UZKUCRlXa7E",
-	"5li2fecCL2a0f04PijTpfPgXNlsfyXWDw5l68O+bD9Sx98KjY1yTYkMWn3zD5ulgYN4Vq9gnVTmIFa6H",
-	"CW5NOqHOovDcd1T25OZ7BRmd0+8mu1UxqffEpJWlbUAL9ucwO4xBty8fpPLqeSFTyIdjGYuDYO/i88gX",
-	"TbBiGJgx6Mb6/digyuYMDHXFHRRnXTC1eeIFW8FTpfJTx8BYE2tNKnVQ3xqx1PPJ5Pn5OZQVplIqvQkT",
-	"WUxqx3fW0bsfc4BSD+uSNenuRO9GtE8GAhmDA33LmdbEK3KVBnWqm/faZd4AU4OZjcEBueOrk2rBDQMt",
-	"RYJO6Nqx5nXDyRpGe9za3vqG3dBl7zzVJfvk6b7uxdH6jGdfnDKuND6d5LhxJtbWQ/df5Vq8XZZOSxIb",
-	"jcyYeoD9JOHkPcoOcK8L+3n7fTbeXGSyD+mP5kyR69sbkklFCibYiotVjXR3VzswpgH9Ckq7MHEYhZGp",
-	"XpYgWMnpnE7DKIzN4Biu7cwmX+NJE3P+Slfgufks7DVVE0ZyrpHIrEFhOmQIYAl1k9I5/RnwU7xoMZZM",
-	"sQIQlKbzB1/jCy54URX13pWZvX676CY4N3ZfKrBXAjc+R+SnghuauINpEO8uH9HIRe2Fw17+Jhz24odz",
-	"ef5v4HHrcK/zBGX99XAMV84Ljn5M07HpZZZpwG4fRqR2bv7cF2NzJ7IoGNFgKISQHlCP8PQACLyUuUyB",
-	"zjOWa/AD46nuoOIIhe7Ao9NgFpzT4+uGMqXYxl2wN9ZDc+s6roqScUUecoZBLlaPuwra5op6c/jgC6f3",
-	"w/in4cUseBfHl+HVlI76UugW5q9DS4VEqtSR8E1sMK5+LrQ76VBVH42s6lIK7RbLWRTZT0cpEIQVKFaW",
-	"OXeLbPJZu9uhpy9Dy7y+g/cbsA16+rd3JzcO52/EMwTD/XPDk/VGICjBcvLe/TfCWCBbGSWljcI+mod7",
-	"Ej55dT+eeLodIef1WVpu6mv9cS13f27SMZre+VKwjDDbZkeIFiLd356oKvCyxLPP/zFBxvBiBA9m0ey/",
-	"58ECtKxUAkRIJJmsRBr+3zi4e/raDLl5u33c/hUAAP//R329PNoTAAA=",
+	"H4sIAAAAAAAC/+x9+XPbONLov4Li298eLUs+4tivtl5lkpl5SU1msjl23m4mnwWJLQsxCTAAaFsz5f/9",
+	"q8ZBghQo0UeOL+uqVMUiG0Cj0Wg0+uJfyVwUpeDAtUpO/kqWQDOQ5s9fGD/H/zNQc8lKzQRPTpK3SyCv",
+	"f3pKHu89fkxyxs8V0YLoJZAFk0qnpJRwkRIOV5pQnpGcKk1KegaKiIWBk8A1zdWIvDJPJXyqQGnIyCXT",
+	"S0LJvJJKSCJ4vjIDtPo3feIvMwD2O0rSRM2XUFBEFq5oUeaQnCR/VOPx/nz3YrLrBvy/OSuY/vtkjG/2",
+	"HonFQoH+u/m1D/+HSMj//kdiBvkjScnA5pNOe0TrBs33Os2RWn8kSZroVYmTUFoyfpZcX6fJ/9/5Fa70",
+	"zlNDnfi6OMrNBdeMV4yfEbrQIIleMmVolZKCKYUvBDdUrFenj4qweiGffyxnzz/+ePDy2ZPL5/lYPS/y",
+	"8+cfxZ+/PH1RPP8o2K+/vzv498d/Hb58+2L5r48v2MtnL85/Xb047pnGW6FpvvNUVFzHp8GrYgYS2cXR",
+	"jhRUz5eItuWDXINUKaFzKZQiNM8tg/XMYe+wxoNxDWcgk2vEpKSSFqAdt//wg7jqoaooCkoUIDyyaUFL",
+	"csHgshRSk/cF47/wsxT/ozot6JX5Ra9+ofoDcq4EXUlezwR5nPEReUJsQ3Imgdo1opzY5kRBDnOtCCUz",
+	"cUXMLP3kKdesAMkyRjnOt1mpydE4nRymO5PDcbp3aF6VucggOVnQXEGaMJzQpwrkKkkTTgtsNJuJqxbV",
+	"mIbC0GMhZEF1cpJkoprl0KylXZ3kOk0KevXcgh+kScF488OBUinpCiGVXhkcsVP8/YMQ54yfPc/iFJ/Z",
+	"14Rlo8ThXVK9DNC2AKcsS9IEBQiTkCUnWlYQZYFJhAPS5GklJfD5ah2Jp4JfgNTKkLyUbA4d4UUYd1Jp",
+	"7jpJCeXk+ZvfyMHe5IjMRQYj8nst0AwImZquTgvGp2n9g15Na6Fmh1LIWJUC279FBTL7EqWtSE0Dj4p5",
+	"nhHGw6GQz0SF4pLA1XxJ+RkQZF9CJZAcFpqISlsMEYxpAhcgV65TwsJumVZEXPK681ESZyb/vkeU/Pju",
+	"dYK7TmuQ2Pq/3j/Z+Tfd+fPDX/vXf4vKik2yTpT0U1WLvIUUhSFXS0oSe5r5tcOTiYnKisIReW12pmot",
+	"K5IeMic2mU5JxeliAXN8OFvVYDTLICNCEgmFuLBUmoG+BOAj8rJSmsyAKODanmk4gqJFLbrM8plVpmod",
+	"M/N2TjnhwvQzF8WMcX8+2oNjwxIgxcIFWCfrjzx7RjWs0/U3PHKlI4ufq15SbdCZ2Y0JGam4Zrk9VTK6",
+	"Si2fLmF+voO8ZB5dLtl8SZSmK0UWEoAshGzObbd/cQ3M3lVkqjSV+jSjGqZ9kwOeGYAe/tob7493xkc7",
+	"48dJGogv22CdCs8zNUze50zp5jAiLFONXB8NlLMsU3Ex26C/nx6kKLYLxllRFVGhtV2y/gL8TC9f0p6z",
+	"rKBX2Dm5gCWb5ygJELwt3FLk5gX0M5lthJKr58DdGx2mkQOkntl47TBpMGe8B3PG7w9zxuOYT26DN+p1",
+	"m6m9rtC0GCiKqek1RDKDBa1ynZzsjYNjfz9N3CjJyWQ83sw+12nykp4P3fiOtJ7gBT2HlEg4ozLLQZn3",
+	"c6qgbwYI37NT/ynyc3VJz4BHd+ZLkUF+SySx6Y2wNGPF0fwdlF7QnNEolr8ClcMESEmZJO9zqtOcn8WU",
+	"Qg5UDpUkCLtVlOyPHh2kO5PJ0eh4P0lvpsvthbrc3naJ85s5kHoOafOuqzttZX7bLL4qj6L7MWTxV5LN",
+	"YasEjGh1fejUiloco6PDMW68msiM60cHyUA0t4m7m6PZJ9uOb4XlP8wYw/bi5VIoIIhNSgLw1IgO1JbM",
+	"ZrOXOXNruxQyq7fwosrzHY3agQIq58vYLjaqEbZCdaIYkX9UwmywpaQKrFo1FXJqlFxVlaXR5kYEhZ59",
+	"azDAK6MSDg+jVKkC0dGrUliw2QpRYpKcc1R8UQYoUCnJ2TmQ6T9/nxp1ZtrIsV6d5VOPbLmom5LLQMzg",
+	"BdCcUSjmxzGx85pmrOrRXCzhiDQghEpRIUE4UDk1R+O04kxPR+SZPUrMTpyMx324237iEzg0h9A8rxS7",
+	"gJeeh+wF7KYn6GvDP32XwVrt6rkL2vd3vAq+yQFKtXU3Ngd5CaLMIdiVRJku+mhp3/bvzoOtO/GNkPpm",
+	"CuuCQW6VVXPXCEXwbIUmiBxohvfs6c7UgChnX4Hc3GhwJOAGQsgM5IggDvhztiLTjClN+RymRNZKvGG1",
+	"NpiEHC66cJ/slbes3JAzUNruR1DejjjdaTW9AIlbnOkRectwN0sgMynOgbvd2maUIUcpTrlnexpZmu6s",
+	"7GHbHLEc1+e9fZ2kyY7/wwH6BlbPxAf1X5YB8FH9l0EjTXbc/3NjCUI23mn+9FTGp8HfNWnwefPjw5rE",
+	"2H5+v9FU6rvdB93te/06iExEV+E1z9/hegVmcw/cds2bDLrmvV2VkZkNuOdhVyqirIkFoXxFxGIomxmc",
+	"tmhsc1qUIHcuKLfS0h8Ck1ss6Dvedx9B8Y/4T61kt5uw1i1wluDNSsQzm2ofFwXLoVfIYf+tmf5NwiI5",
+	"Sf7XbuNk2LVv1e4zN4BBF1fqnQLZdwhUCmT/EYBv73gA/AuoHHxrxl0+TCdDyA0X5fHeeKvcN5gNvRXf",
+	"FLO+42hvPNmG2TVSW5WCKzDM/EqKWQ4F/jkXXIO17tOyzNmcIsq7pYX43x+VMNMZxie+XzNimwLvOFyV",
+	"1jgHUgrpFLSD8cToZ97XMZeQAdeM5iolB8fH5iX1jicrrnIGXJMzegGkKong6R/8cLxPLpeAZtUMSuCZ",
+	"Maw64j559RyNpBWnF5TldJYbBfdwfGCbWPq7/lmBxsJKj/7gZkXdzHDiT2xrljO9iq8wDSCM7PEb1HoS",
+	"8Lcx7ooFilqzNUspSpCa2XWpEVzv//cl6CXI8PTsCHZntLtcihzsQC2ng5N6jjdmQuRAOcogPBHi87Fe",
+	"vIzWlFzv9UYiPg0UwO364yYpkCZaxLtAbL0nzfnMhkzg8cAJBEwUH78EyUTmnUfNoN2FcwbjZvFSq7hZ",
+	"47U5WUehOrNp271rkHplhl8/eq5Defu+pYib9TcETQMGbE+1UVbE7CPMdeAYWifDk9opFOyBdWb3ilOM",
+	"1S3hfDeXVBEHHVu7RzvjydvJ+GSM//7dXcUd3NOxpZz3upRwHVvuIc8+von/rYWxZMZe+Z2Pc2HaTMGu",
+	"dXsG7948i+FWm8/j2lDbfh8Mx5RlLMtVgteaXs+mfTyE59kg19/m7crZ2VKrbT5kCxUj1FGs0y3ShNUK",
+	"k6NHw4mbkQ302g30dwrzHaSh0lRXW3e322dvLLARfZrmPdInNEHZk8CtkjEqzLHjLjO36TF5vB+1Pa1T",
+	"qSqzgfvXztMKY+vfvM997DXKLTyAYIYD0JkVjn4YVeVCaenU1UZkNkps6woUOL0cv9dr7FctDT2vzdXR",
+	"03KDnH3Oy0rHhG2tuwhD8A0S95uSKl9qk31+9tjGDRsW9U0tAfrFq+Ugg5M3aVhLD3KQ4AsmC8NDc8rn",
+	"kOeWi4LragCyRpwWGu/K/tXgcOm3sTnUvVd4jcduJdM6BHV9xOjWuoluvDjT+lqc2pswKv3nLBc2jiik",
+	"Z8GSNDkv2nQ7L2IE+4nOQQ+Kh6rjoMJwIkLJBc0rGBHTkbKGDOS/QqBwFEUhuAVxxrWI3uRH38SiaWI6",
+	"uZEvL1wC2zp1g8VW4ic0Pf4oZSzo4wlOgWXmKmmve+4eh3e8vLluGfPl+gzN4x760qI+3xg3ozS9dGyC",
+	"7sq8toYFKEXPonesVWBVRZVNwkdzZ233XrioEcqJJ/g2etopNWPHKPoziBdvfvv1Z0AWjXlynhAHQl6J",
+	"fHUmOLL0yyrXzP82PPY+x+M+x6C2UiiGjVWMjYTMGKca+mIrcqGMqY0DlQRn5fa+GwwvLITWpjhtPEp2",
+	"mUOcWqR7//79zmTyeHSY7u+NDj+k+Ouo/feB/9tAtX8h3IcPza2oe81Jk6udM7HjnqHtYvSaXr50NK+h",
+	"Y7P1lD1z1Dc2xVBIuOkkaRLOri0zGpjN3GDepq0liDHEL4wDOndj1l48IKm0xgRKPlVCw/oa06JfUn2q",
+	"KNdoqNCsAGvaN6LTtmnUxRH5Fc6oZhd2cTOmjExQrXV9fHA4UGtsIbLO4MuqoJxIoJmx0wSva7XVTLs1",
+	"evIralto/+7RAfxct8lsJIByI2TOQmU1uW0qwSbWOmfWdBtg7niKW7yTNLkEOAdudAigSnCau4f56tST",
+	"PEmTQnC9bD+CKy3p6RnKU4U8lQPlGG+5AEMKetVm0WbIiG2D6dNNLFPfLmpBjk0CVmndJB4dD2KJ+NYI",
+	"Bw/Wr41k6hk8unmENWVutDTlDigiHnvZxTVFgDYXvqGc/CQpn+PiRC0OiKzc0q2F6VoJnsT6y6nePDuq",
+	"ma6y9mbZPxodHR0cD4o0yfnZ5gEEP1sfYbK3NzqYHB8MGgJ1Pdg4iIFo0+NplBx/snJjR3+ystXN8cFk",
+	"vL9NVM8t21k07RjNQtolsHTaxIOvUBfss7Eax2/bauTZEu909mqYekDj0qMSzI2slGAiV/H3OZT6gY0f",
+	"2Lhh4zV2NGFMG/s2Ej7CRXc3ldqeyWsflS3Bm3Tx+Hj35lltKmXKe4NiptJOTHpPQHqaZHS1faakBGmt",
+	"HfEj7DYHGA78oY/4P1TzcxicSuPC+o3LfoqZCOjv0iIls0oTE9GTGcveFBMThlwUD2KKS0GvWkCT8d54",
+	"MkiXKxhvtdwf34Zg9pZmHa/9101DPrQX9NxW8LIv2awKVUWXheEW2T/tXsybxTc+dtbyCELWcLJRB+t+",
+	"fYLAiLx0vkvDwFx4DnMxMuvrsmRKizNJiw1algpXXQu7xESVOdM2n4VqazKYjAl8qmieYx5JBmRmOMxM",
+	"yvEHgaJEfyS3uAzyKIXMGglj6LJMX1hlhGUAs6Ha/DZQUbw1v6VJuXe4SaOlGq+yM8jFpUuGwBsVlRpk",
+	"Nx6WSteo4/OYDEbl6Iao6KUE8OioofgcH991K5YmMc2tl8U7DVg3vkfrsILOvY6bPNSjx+Mj4iILSAaa",
+	"MozRMc3TJqilTsPpi0cgLmbBXNHNpdTmQxlDU0wMZj2HntJ4ycSYVxQIsFNfO8ODzI08Iu94LQAs7ime",
+	"VpkAax13iVsutYhIyIEqUKM/+A6ZOlPVqZMq01bQAVPelJWSqZmEmhqzijLpXC0zl+uPC326wJBR2xOG",
+	"2DSYwBVT2sIVoJciO0VwmufiEqINXPiteWNb2NbO5dEMlpKpMXa3nvgEvw5OEpSo5ByiePk8t1NJNahO",
+	"UwlOwW2nw6k6xKH2XIVYBu7qacd5QXNc2VUYKaFEY0dE/drR1c/FWqBP0Xaes7lbL/eydt873FxyoW1i",
+	"u2m38+vsn6qGxbEReDe5pZftoeK00ksh2Z+QtXvJ6fxcWftqGC3jaWGATq0vwDddi5hpdTiDhZDgndQK",
+	"5AVkNRINSYdF1qStrgu6IqqazwEyezpa9U6ClswPolkBouqQqo7DMXtsSDeMa5Cc5qdmA7V7W1CWe8cV",
+	"2BlKDPBpDDGd/ZmkSc2SRgh2d1HjDwzh2nsjSZO1rWFkdJz1my4DcgZ9dFjSOX/cnyG7uNC6kA060Rxp",
+	"4ohu4s1CurVtRetUWVezjSTsUcmchKczdCoan6GYu73bFa8pKYBybfamsQG2NXGPCtmAipWccVQ6zZ0s",
+	"DQ4dL1o6U/boDdecArdIRHFi3IUFx1UAGmbJNRFv/sh01DPYtskTFC/YHGiwPuj/e/v2lXftmXPP06Q1",
+	"wsF4HDV6Mt0XCGVe+dkMGyT5gWbkdf8K95tY8c0aRxm+O5nllJ/75F0ztoY8VyGoIrR08VYNLkHrofZ8",
+	"S43A5+92R7DuqVVHYtqTSZDZbnjVGOCmN8VV3e2yjtk11qyqNkU2DQ1jGhBr0BOPNyiKwNm9t1yoLVRr",
+	"nEcxds4Zh9N6m8fQRgt+rYTjWqSYYI5agbmaN1FhgwVG7eSJiIsbRU0FSN05dOoGgZgm5uF2IRxDgy5v",
+	"FvBkVyVu0DkcHx4c3fxeFMb+2Pla3g7ifGoHTMBDkaCf2Ma3NrF4aI9ZBgt6mwhKH4XYH0C5N9mZTHb2",
+	"jt/u7Z0c7J2MH40Ojh4fHh8Oj7/a6NELmCl40UbiLWoGaPVbkdf/jI7ANp2a/m1TWMLmNpFSMK7X7TnW",
+	"ZyWafBmvVdqUPGPJQf3c9tIxCY+GmYPvYV+5bKRN3ViQVld749FhzAIeOMM2CiMPZ4w855uNxQjQXsmf",
+	"hIyGGRXxLPGwL4TodIblcWK92ayIDZ0hQLuvl32sVXqT+FZrmIMuqFydsoKewWkl820mZoQmBppUsjO/",
+	"pdalOtndvby8HIlKZ0JItRrNRbHrGu6YhlF9zqalbRq8SXHcbP3t16mC3KqO2yKnSpGo72J7TGggkUw8",
+	"qGtx/2IJL2NbA+eVJcKqtz6AQ3dVZ/03STfHw+JGXcZg26HtFEZXd8EXNuhmJNbZh+t8l9Z5jfXWdlPu",
+	"P2FsyFlfgl3lDeD2SI3Z09dKfFEnM3t8D5L1xRatBceVIEOf4bCrVhOLF7VRn99odISPpLGnRpqYpAw0",
+	"xoVheqqEPHduqntAeLgosm6QLXIgOslYUvTKyop7moWxaN0EJdMgoLL23ChXhh3Ne/JRmLJKsxXaoaha",
+	"+vz+d292nj6Z3hPuG/TM/rjO7bo2PrkJRbzEvfOMurdUp4xafPwOqZcsDTZtzVr90qQnHh1nBRkzlvUg",
+	"uKH/uvr51ccHTepBk/qfp0ndIG1BXHJfc3JoitNn0niaVIgvpvb0S6gNUVcbJNTdY66+c4lmyfog1h7E",
+	"2nct1nqEinpjbjwxa50r6bQQslNCmFCO4oKui4qzINdjE4N1U0O6crfuJyYN19PDI8jbvHW0lvkImMZ/",
+	"7hP/JJnlYn7e3IbEJQd50zzDSIZ+WJrhjmmF0oTP9+f2NLMKBg1j8u1kkzRxc227ROu3N85ntMUUhs10",
+	"WLmcTspcNA/VkSPKFs5A0uWEcO8Fd5xuipZU+nSroDV92Zmvy9wXYslvkm9u+9xuOKWDMTPMt47YM7Gd",
+	"4LZ0QkOFcNx1amNrxheRmhW/ecFugilMKRTK6VlA/Nqx2AFO0gQLbdluJqPxaIyzFyVwWjKsLDkajyY2",
+	"YnZp1gydxC6aQO3+1dTlvraSKHKZ82WX63xPs/GNsQ6ZwagDWAYo+Rm0LwfRLtb+Pi7XGpDdpr749YdO",
+	"mZq98XhDiZqblabx6EVK07hXpNkaBxsHvvfaOM9ddEJYUMFgcfAlsXjtA7ZQyzUhKQaLwy9NCxuPQn60",
+	"0XwGwFXTjXdas0zQbZpoeobs5xdXJR/MZwSiN4KnJiBHBWFc7SRn8oRMXc71tBUFNgMyrfOrp3hATuss",
+	"7GlKaOtt2NB4n2YQghtjF2096QaczcCXTyCUrwohYX0n2iTudn71Xbek8Zz9ILLVfe/GVt759fV1tyjY",
+	"9dcRCCaN3Polah74imLBFwD4ZkTCwfj4S2Lxtj/20rh8Tc6mcbBrv31dJEbj9BUcvjNpdp22or62H+Ht",
+	"mokqeoi/rlWOm0mMukDydToQll4NgbUF2wcAusrWAyDdhyoGQOJXBwaAmeLiA+BcNeABkLbM4nY48x2c",
+	"AXBN2dABwP6LEwNATb3OAXCmkP0QOGMFGgDo6ywOBR3GbE1h4SGcWX8CYTjwMDRsGe8h+Ao5lONt8NGd",
+	"FexBHikrQyLeqMjxEoQ3JWnsg2axgRzYroGJfW1rU6M2cOwjV5tbh8DX119PKTAlQkmzyt/D6eZPH6Oq",
+	"CxU5zZ5KMJkgoTuxfYhZiNfeVPA5lNfQ/zlIaZ3c89B9SpJPqG1oE+6ojfUQ3r3+pQ4GXuslVto50D12",
+	"9ycRc8lX3Bse8+9pR7TVvd1FHcuzUevTTWhPXwyPbj5G4IMPUvcBGRea0YrGMGdkWn8FLRImxKRLYU1t",
+	"RW6eOVOv/6KWWASDjsiT+RxKrZovb3VFGwbvT3/+8S0JCDC1KLi5wRWdY/2XcIJM2xy6psQ9NijRtGYW",
+	"3zjw2BkXErINurALmvpWFOIHnfRBJ/2WddL70zW3H4Nua/YchlZC9iX9f017zvetunUOKlW7S+MaXXhS",
+	"dSwUTdl0X5yt9JXnrJ+kdGcIuBCNTFhDFdPGeFvZ76HMxNVtD5lRXWgPsjOTgIzpn7aTpZDaZAhi0p7P",
+	"+taXoqm5lxIlPMqq/9OwHu0lpudiEVewdR7WDyXren6w0Tychw/n4fdto/lcl1YfvfIZfC0PtqFv1jaE",
+	"jkkfIfSNKBuH4/0v7ULKqKYzqoAsqSJckFdC6Z+fv/HfP3YHdaNScIDM1LjtxAqRt0FK/tIllrdrZZhP",
+	"in8+neqvOh/12ipUOcSijp6Z55uMZRaiNpbdTJ+oPwUYUfUPNsbeYPKXRTr7mnp4kIz5EG1x/xbcLe5I",
+	"R/3+gKI7s+W3dVvdfsh9YzfShw1x3y6NePSRDX8JvufZTkfw+QbtPPaUnAOUxqqZ50TopfukQXsXmVD9",
+	"+xHvn0sjddkEXzb2Z7MbxYf+BG6Er+/IeNiN970bq+jxVOZ0jjpTnm9LY4zF3X3be+0GLsuHvfaw1z7/",
+	"7WWXdr6pudWZ2fm8YueLmrdNX+nRPVtf/LyTHjr025roMsVnph5NUAU79lVY993Gu393+Zaf0UzJ/thS",
+	"3QJOEaFpG3Xy1kP7eGlft3j/0SPbOBf9U9Ri4wSH1Y/6rLp9i0Uiu+pJ65OwD3r+f7S088k3/d4wjO8N",
+	"Lsfu48etjwaT1qc367pexn9VJymYQubmK+BOBjZlyzvV0N1IzUeAunXl+kKsbpvv89l1ndb3Er9wfNaW",
+	"pAK/VsHX8+4SoRV00xeiVed7fWMhWu20iv/QpIbeCtWBqiJ4p1b1Sn3XiQwtgfnJl0Tdrhf2V0hNyUyK",
+	"c0A/+yW3H0/IGQdiHFYnprX7KFZK3Ge4bKib+xCXLT5ua6HaL3Lh+rgvcdXfQ7PvVSXdZ9lQdNuakGQG",
+	"K8GzULjqbhp+6up02293kQVAHWGn6VWr4qiGTh6zqd9gPqOyQZW1xWXvWYft1veMqXC+XGZbBt+DVpdu",
+	"rytLXJH/Fam/2KhJDqjMUqt4+rrABeOsqIreoqqek3BRbqTBAs9uOvnHt518t95tSiaDLhJ16dIIWgdp",
+	"4mgTzbb+rLq15dmIMDIvHrTp/yBtulIojv5y1UOGJKwj6Abnkil+cFNxiI0+d566QSxCYnz+9VneUPXB",
+	"YXp/nI7L2s/ng7M7Y9GTaN1yFROxv1vHQPZtn9vGIfpdlD5ELD5ELD5ELD5klT5EDn7bWaUPB/19HvTN",
+	"s7/8BcyfpNdp/ciCBw9qg8n1h+v/HgACAYizCaoAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -1,12 +1,11 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
-	"time"
+	"syscall"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -14,31 +13,80 @@ import (
 	"github.com/toshko07/outdoorsy-challenge/internal/configs"
 	"github.com/toshko07/outdoorsy-challenge/internal/controllers"
 	"github.com/toshko07/outdoorsy-challenge/internal/db"
+	"github.com/toshko07/outdoorsy-challenge/internal/metrics"
 	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
 	"github.com/toshko07/outdoorsy-challenge/internal/services"
 )
 
 func main() {
 	// Setup
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate(configs.LoadDBConfig(), os.Args[2:]); err != nil {
+			log.Fatalf("failed to migrate: %v", err)
+		}
+		return
+	}
 	cfg := configs.LoadConfig()
+
 	e := echo.New()
 	e.Use(middleware.Logger())
+	e.Use(metrics.Middleware())
 
 	// Database
 	database := db.Connect(cfg.DB)
+	migrator, err := db.NewMigrator(database)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+	if err := metrics.RegisterDBStats(database, cfg.DB.Name); err != nil {
+		log.Fatalf("failed to register database metrics: %v", err)
+	}
 
 	// Repositories
+	usersRepo := repositories.NewUsersRepo(database)
 	rentalsRepo := repositories.NewRentalsRepo(database)
+	bookingsRepo := repositories.NewBookingsRepo(database)
+	pricingRepo := repositories.NewPricingRepo(database)
+	healthRepo := repositories.NewHealthRepo(database, migrator)
+	exchangeRates, err := repositories.NewFileExchangeRates(cfg.ExchangeRatesFile)
+	if err != nil {
+		log.Fatalf("failed to load exchange rates: %v", err)
+	}
 
 	// Services
-	rentalsService := services.NewRentalsService(rentalsRepo)
+	rentalsService := services.NewRentalsService(rentalsRepo, usersRepo, exchangeRates)
+	usersService := services.NewUsersService(usersRepo, rentalsService)
+	bookingsService := services.NewBookingsService(bookingsRepo, usersRepo, rentalsService)
+	pricingService := services.NewPricingService(pricingRepo, rentalsService)
+	healthService := services.NewHealthService(healthRepo, migrator.LatestVersion(), cfg.ReadinessTimeout)
 
 	// Controllers
 	rentalsController := controllers.NewRentalsController(rentalsService)
+	usersController := controllers.NewUsersController(usersService)
+	bookingsController := controllers.NewBookingsController(bookingsService)
+	pricingController := controllers.NewPricingController(pricingService)
+	healthController := controllers.NewHealthController(healthService)
+
+	server := controllers.NewServer(rentalsController, usersController, bookingsController, pricingController)
+	if err := controllers.RegisterHandlers(e, server, controllers.ValidatorOptions{}); err != nil {
+		log.Fatalf("failed to register handlers: %v", err)
+	}
+	docsController, err := controllers.NewDocsController(cfg.ServerURL(), cfg.DocsPath)
+	if err != nil {
+		log.Fatalf("failed to create docs: %v", err)
+	}
+	controllers.RegisterDocsHandlers(e, docsController)
+	controllers.RegisterHealthHandlers(e, healthController)
 
-	v1 := e.Group("/v1")
-	v1.GET("/rentals/:rental_id", rentalsController.GetRental)
-	v1.GET("/rentals", rentalsController.GetRentals)
+	// Metrics, served by a server of their own when they have a port of their own
+	var metricsServer *echo.Echo
+	if cfg.MetricsPort != 0 {
+		metricsServer = echo.New()
+		metricsServer.HideBanner = true
+		metrics.RegisterHandlers(metricsServer)
+	} else {
+		metrics.RegisterHandlers(e)
+	}
 
 	// Start server
 	go func() {
@@ -46,15 +94,27 @@ func main() {
 			log.Fatalf("failed to start server: %v", err)
 		}
 	}()
+	if metricsServer != nil {
+		go func() {
+			if err := metricsServer.Start(fmt.Sprintf(":%d", cfg.MetricsPort)); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("failed to start metrics server: %v", err)
+			}
+		}()
+	}
 
-	// Wait for interrupt signal to gracefully shutdown the server with a timeout of 10 seconds.
+	// Wait for the interrupt of a terminal or the termination of the orchestrator to shut down gracefully.
 	// Use a buffered channel to avoid missing signals as recommended for signal.Notify
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
-	<-quit
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	if err := e.Shutdown(ctx); err != nil {
-		log.Errorf("failed to shutdown server: %v", err)
-	}
+	quit := make(chan os.Signal, 2)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	sig := <-quit
+	log.Infof("received %s, shutting down", sig)
+
+	// A second signal gives up on the shutdown.
+	go func() {
+		sig := <-quit
+		log.Errorf("received %s again, exiting immediately", sig)
+		os.Exit(1)
+	}()
+
+	shutdown(e, metricsServer, healthService, database, cfg.ShutdownDelay, cfg.ShutdownTimeout)
 }
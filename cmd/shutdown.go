@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"github.com/toshko07/outdoorsy-challenge/internal/services"
+)
+
+// shutdown stops the servers gracefully. It fails the readiness first and keeps serving for the delay, so the
+// load balancers stop sending requests before the server stops accepting them, then waits up to the timeout
+// for the requests in flight, and closes the database once no request can use it anymore. The metrics server,
+// which may be nil, gets a timeout of its own, so a slow drain of the API does not cut it off.
+func shutdown(e, metricsServer *echo.Echo, healthService services.Health, database io.Closer, delay, timeout time.Duration) {
+	healthService.ShutDown()
+	log.Infof("failing readiness, draining traffic for %s", delay)
+	time.Sleep(delay)
+
+	log.Infof("stopping server, waiting up to %s for the requests in flight", timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := e.Shutdown(ctx); err != nil {
+		log.Errorf("failed to shutdown server: %v", err)
+	} else {
+		log.Info("server stopped")
+	}
+
+	if metricsServer != nil {
+		metricsCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if err := metricsServer.Shutdown(metricsCtx); err != nil {
+			log.Errorf("failed to shutdown metrics server: %v", err)
+		}
+	}
+
+	log.Info("closing database connections")
+	if err := database.Close(); err != nil {
+		log.Errorf("failed to close database connections: %v", err)
+	} else {
+		log.Info("database connections closed")
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toshko07/outdoorsy-challenge/internal/controllers"
+	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
+	"github.com/toshko07/outdoorsy-challenge/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeDatabase struct {
+	closed atomic.Bool
+}
+
+func (d *fakeDatabase) Close() error {
+	d.closed.Store(true)
+	return nil
+}
+
+// startServer serves e on a free port, returning the URL it is reached at.
+func startServer(t *testing.T, e *echo.Echo) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	e.HideBanner = true
+	e.HidePort = true
+	e.Listener = listener
+	go func() {
+		_ = e.Start("")
+	}()
+
+	return "http://" + listener.Addr().String()
+}
+
+func TestShutdown_FailsReadinessBeforeClosingTheListener(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	healthRepo := repositories.NewMockHealth(ctrl)
+	healthRepo.EXPECT().Ping(gomock.Any()).Return(nil).AnyTimes()
+	healthRepo.EXPECT().SchemaVersion(gomock.Any()).Return(1, nil).AnyTimes()
+	healthService := services.NewHealthService(healthRepo, 1, time.Second)
+	e := echo.New()
+	controllers.RegisterHealthHandlers(e, controllers.NewHealthController(healthService))
+	url := startServer(t, e)
+	database := &fakeDatabase{}
+	res, err := http.Get(url + "/readyz")
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	// When
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		shutdown(e, nil, healthService, database, 500*time.Millisecond, time.Second)
+	}()
+
+	// Then
+	assert.Eventually(t, func() bool {
+		res, err := http.Get(url + "/readyz")
+		if err != nil {
+			return false
+		}
+		res.Body.Close()
+		return res.StatusCode == http.StatusServiceUnavailable
+	}, 400*time.Millisecond, 20*time.Millisecond, "readiness must fail while the server still accepts requests")
+	assert.False(t, database.closed.Load())
+
+	<-done
+	_, err = http.Get(url + "/healthz")
+	assert.Error(t, err, "the listener must be closed once the shutdown returns")
+	assert.True(t, database.closed.Load())
+}
+
+func TestShutdown_FinishesRequestsInFlight(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	healthService := services.NewMockHealth(ctrl)
+	healthService.EXPECT().ShutDown()
+	database := &fakeDatabase{}
+	started := make(chan struct{})
+	e := echo.New()
+	e.GET("/slow", func(e echo.Context) error {
+		close(started)
+		time.Sleep(300 * time.Millisecond)
+		if database.closed.Load() {
+			return e.String(http.StatusInternalServerError, "database closed")
+		}
+		return e.String(http.StatusOK, "done")
+	})
+	url := startServer(t, e)
+	metricsServer := echo.New()
+	startServer(t, metricsServer)
+
+	type response struct {
+		status int
+		body   string
+		err    error
+	}
+	responses := make(chan response, 1)
+	go func() {
+		res, err := http.Get(url + "/slow")
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		responses <- response{status: res.StatusCode, body: string(body), err: err}
+	}()
+	<-started
+
+	// When
+	shutdown(e, metricsServer, healthService, database, 0, 2*time.Second)
+
+	// Then
+	select {
+	case res := <-responses:
+		assert.NoError(t, res.err)
+		assert.Equal(t, http.StatusOK, res.status)
+		assert.Equal(t, "done", res.body)
+	case <-time.After(time.Second):
+		t.Fatal("the request in flight did not finish")
+	}
+	assert.True(t, database.closed.Load())
+}
@@ -1,6 +1,11 @@
 package configs
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
 	"github.com/labstack/gommon/log"
 
 	"github.com/joho/godotenv"
@@ -11,6 +16,33 @@ type Config struct {
 	Env        string `required:"true"`
 	ServerPort int    `required:"true" split_words:"true"`
 	DB         DB     `required:"true"`
+	// ExchangeRatesFile is the JSON file the exchange rates between the rental currencies are read from.
+	ExchangeRatesFile string `required:"true" split_words:"true"`
+	// PublicURL is where the clients reach the API, listed as its server in the served API spec.
+	// The API is listed at localhost when it is missing.
+	PublicURL string `split_words:"true"`
+	// DocsPath is where the page browsing the API spec is served.
+	DocsPath string `default:"/docs" split_words:"true"`
+	// ReadinessTimeout limits how long the readiness probe waits for the database.
+	ReadinessTimeout time.Duration `default:"2s" split_words:"true"`
+	// ShutdownDelay is how long the server keeps serving once its readiness fails on shutdown,
+	// so the load balancers stop sending it requests before it stops accepting them.
+	ShutdownDelay time.Duration `default:"5s" split_words:"true"`
+	// ShutdownTimeout limits how long the server waits for the requests in flight once it stops accepting
+	// new ones. The requests still running after it are cut off.
+	ShutdownTimeout time.Duration `default:"10s" split_words:"true"`
+	// MetricsPort serves the metrics on a port of their own, which can be kept internal. They are served
+	// along with the API when it is missing.
+	MetricsPort int `split_words:"true"`
+}
+
+// ServerURL returns the URL the clients reach the API at.
+func (c Config) ServerURL() string {
+	if c.PublicURL != "" {
+		return c.PublicURL
+	}
+
+	return fmt.Sprintf("http://localhost:%d", c.ServerPort)
 }
 
 func readConfig(filename string) (*Config, error) {
@@ -34,3 +66,29 @@ func LoadConfig() Config {
 
 	return *config
 }
+
+// readDBConfig reads only the DB_ settings. The file is optional, as the jobs running the migrations
+// usually set nothing but the environment.
+func readDBConfig(filename string) (*DB, error) {
+	if err := godotenv.Load(filename); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load config file: %w", err)
+	}
+
+	config := DB{}
+	if err := envconfig.Process("DB", &config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// LoadDBConfig loads the settings of the database alone, for the migrate command, which does not need
+// the settings of the server.
+func LoadDBConfig() DB {
+	config, err := readDBConfig(".env")
+	if err != nil {
+		log.Fatalf("failed to read config: %v", err)
+	}
+
+	return *config
+}
@@ -0,0 +1,30 @@
+package configs
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDBConfig(t *testing.T) {
+	// Given
+	t.Setenv("DB_HOST", "db.internal")
+	t.Setenv("DB_PORT", "5432")
+	t.Setenv("DB_USERNAME", "rentals")
+	t.Setenv("DB_PASSWORD", "secret")
+	t.Setenv("DB_NAME", "rentals")
+	t.Setenv("DB_STATEMENT_TIMEOUT", "1m")
+
+	// When
+	config, err := readDBConfig(filepath.Join(t.TempDir(), ".env"))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "db.internal", config.Host)
+	assert.Equal(t, "rentals", config.Name)
+	assert.Equal(t, 25, config.MaxOpenConns)
+	assert.Equal(t, time.Minute, config.StatementTimeout)
+}
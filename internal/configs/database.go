@@ -1,9 +1,37 @@
 package configs
 
+import "time"
+
 type DB struct {
 	Host     string `required:"true"`
 	Port     string `required:"true"`
 	Username string `required:"true"`
 	Password string `required:"true"`
 	Name     string `required:"true"`
+
+	// MaxOpenConns caps the connections the pool opens, so the application can not exhaust the connections
+	// the database allows. MaxIdleConns of them are kept open between the requests.
+	MaxOpenConns int `default:"25" split_words:"true"`
+	MaxIdleConns int `default:"25" split_words:"true"`
+	// ConnMaxLifetime and ConnMaxIdleTime close the connections which are open, or idle, for longer,
+	// so the pool follows the failovers and the load balancers in front of the database.
+	ConnMaxLifetime time.Duration `default:"30m" split_words:"true"`
+	ConnMaxIdleTime time.Duration `default:"5m" split_words:"true"`
+	// ConnectTimeout limits the wait for a new connection, which is rounded to seconds.
+	ConnectTimeout time.Duration `default:"5s" split_words:"true"`
+	// ConnectAttempts is how many times the database is pinged on startup, backing off between the attempts,
+	// before the application gives up on it.
+	ConnectAttempts int `default:"5" split_words:"true"`
+
+	// SSLMode is one of disable, require, verify-ca and verify-full. The certificates are the paths of
+	// the PEM files, the root one verifying the server, and the client ones authenticating the application.
+	SSLMode     string `default:"disable" split_words:"true"`
+	SSLRootCert string `split_words:"true"`
+	SSLCert     string `split_words:"true"`
+	SSLKey      string `split_words:"true"`
+
+	// ApplicationName tells the connections of the application apart in pg_stat_activity.
+	ApplicationName string `default:"outdoorsy-challenge" split_words:"true"`
+	// StatementTimeout cancels the queries running for longer, 0 disabling it.
+	StatementTimeout time.Duration `default:"30s" split_words:"true"`
 }
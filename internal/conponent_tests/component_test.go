@@ -1,12 +1,16 @@
 package conponent_tests
 
 import (
+	"fmt"
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/api"
 	"github.com/toshko07/outdoorsy-challenge/internal/controllers"
 	"github.com/toshko07/outdoorsy-challenge/internal/db"
 	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
@@ -26,29 +30,93 @@ func TestMain(m *testing.M) {
 	db.SetupTestData(testDb)
 
 	// Repositories
+	usersRepo := repositories.NewUsersRepo(testDb)
 	rentalsRepo := repositories.NewRentalsRepo(testDb)
+	bookingsRepo := repositories.NewBookingsRepo(testDb)
+	pricingRepo := repositories.NewPricingRepo(testDb)
+	migrator, err := db.NewMigrator(testDb)
+	if err != nil {
+		panic(err)
+	}
+	healthRepo := repositories.NewHealthRepo(testDb, migrator)
+	exchangeRates, err := repositories.NewFileExchangeRates("../db/test_data/exchange-rates.json")
+	if err != nil {
+		panic(err)
+	}
 
 	// Services
-	rentalsService := services.NewRentalsService(rentalsRepo)
+	rentalsService := services.NewRentalsService(rentalsRepo, usersRepo, exchangeRates)
+	usersService := services.NewUsersService(usersRepo, rentalsService)
+	bookingsService := services.NewBookingsService(bookingsRepo, usersRepo, rentalsService)
+	pricingService := services.NewPricingService(pricingRepo, rentalsService)
+	healthService := services.NewHealthService(healthRepo, migrator.LatestVersion(), 2*time.Second)
 
 	// Controllers
 	rentalsController := controllers.NewRentalsController(rentalsService)
+	usersController := controllers.NewUsersController(usersService)
+	bookingsController := controllers.NewBookingsController(bookingsService)
+	pricingController := controllers.NewPricingController(pricingService)
 
-	v1 := echoInstance.Group("/v1")
-	v1.GET("/rentals/:rental_id", rentalsController.GetRental)
-	v1.GET("/rentals", rentalsController.GetRentals)
+	server := controllers.NewServer(rentalsController, usersController, bookingsController, pricingController)
+	if err := controllers.RegisterHandlers(echoInstance, server, controllers.ValidatorOptions{ValidateResponses: true}); err != nil {
+		panic(err)
+	}
+	docsController, err := controllers.NewDocsController("http://localhost:8181", "/docs")
+	if err != nil {
+		panic(err)
+	}
+	controllers.RegisterDocsHandlers(echoInstance, docsController)
+	controllers.RegisterHealthHandlers(echoInstance, controllers.NewHealthController(healthService))
 
 	exitCode := m.Run()
 	shutdown()
 	os.Exit(exitCode)
 }
 
+func TestGetOpenAPI(t *testing.T) {
+	var spec struct {
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+		Paths map[string]interface{} `json:"paths"`
+	}
+	apitest.New().
+		Handler(echoInstance).
+		Get("/openapi.json").
+		Expect(t).
+		Status(http.StatusOK).
+		End().
+		JSON(&spec)
+
+	assert.Len(t, spec.Servers, 1)
+	assert.Equal(t, "http://localhost:8181", spec.Servers[0].URL)
+	assert.Contains(t, spec.Paths, "/v1/rentals")
+}
+
+func TestGetReadiness(t *testing.T) {
+	apitest.New().
+		Handler(echoInstance).
+		Get("/readyz").
+		Expect(t).
+		Body(`{
+			"status": "up",
+			"dependencies": {
+				"database": {"status": "up"},
+				"migrations": {"status": "up"},
+				"shutdown": {"status": "up"}
+			}
+		}`).
+		Status(http.StatusOK).
+		End()
+}
+
 func TestGetRentalById(t *testing.T) {
 	apitest.New().
 		Handler(echoInstance).
 		Get("/v1/rentals/1").
 		Expect(t).
 		Body(`{
+			"created": "2021-11-29T22:42:06.478595Z",
 			"description": "ultrices consectetur torquent posuere phasellus urna faucibus convallis fusce sem felis malesuada luctus diam hendrerit fermentum ante nisl potenti nam laoreet netus est erat mi",
 			"id": 1,
 			"length": 15,
@@ -64,11 +132,13 @@ func TestGetRentalById(t *testing.T) {
 			"model": "Bay Window",
 			"name": "'Abaco' VW Bay Window: Westfalia Pop-top",
 			"price": {
+				"currency": "USD",
 				"day": 16900
 			},
 			"primary_image_url": "https://res.cloudinary.com/outdoorsy/image/upload/v1528586451/p/rentals/4447/images/yd7txtw4hnkjvklg8edg.jpg",
 			"sleeps": 4,
 			"type": "camper-van",
+			"updated": "2021-11-29T22:42:06.478595Z",
 			"user": {
 				"first_name": "John",
 				"id": 1,
@@ -86,14 +156,140 @@ func TestGetRentalByIdNotFound(t *testing.T) {
 		Get("/v1/rentals/404").
 		Expect(t).
 		Body(`{
-			"details": "rental with id 404 not found",
+			"type": "about:blank",
+			"title": "Not Found",
 			"status": 404,
-			"title": "Not Found"
+			"detail": "rental with id 404 not found",
+			"instance": "/v1/rentals/404",
+			"code": "rental_not_found"
 		}`).
+		Header(echo.HeaderContentType, controllers.MIMEApplicationProblemJSON).
 		Status(http.StatusNotFound).
 		End()
 }
 
+func TestGetRentals_ByAttributes(t *testing.T) {
+	var rentals []api.Rental
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/rentals").
+		Query("type", "camper-van").
+		Query("make", "volkswagen").
+		Query("sleeps_min", "4").
+		Query("year_min", "1980").
+		Query("length_max", "15").
+		Expect(t).
+		Status(http.StatusOK).
+		End().
+		JSON(&rentals)
+
+	ids := make([]int, len(rentals))
+	for i, rental := range rentals {
+		ids[i] = rental.Id
+	}
+	assert.Equal(t, []int{2, 10}, ids)
+}
+
+func TestGetRentals_ByQuery(t *testing.T) {
+	var rentals []api.Rental
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/rentals").
+		Query("q", "VW").
+		Query("sort", "relevance").
+		Expect(t).
+		Status(http.StatusOK).
+		Header("X-Total-Count", "7").
+		End().
+		JSON(&rentals)
+
+	ids := make([]int, len(rentals))
+	for i, rental := range rentals {
+		ids[i] = rental.Id
+	}
+	assert.ElementsMatch(t, []int{1, 2, 3, 7, 10, 11, 15}, ids)
+}
+
+func TestGetRentalFacets(t *testing.T) {
+	var facets api.RentalFacets
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/rentals/facets").
+		Query("make", "toyota").
+		Expect(t).
+		Status(http.StatusOK).
+		End().
+		JSON(&facets)
+
+	assert.Equal(t, 3, facets.Total)
+	assert.Equal(t, []api.FacetCount{{Value: "Toyota", Count: 3}}, facets.Makes)
+	assert.Equal(t, int64(11000), facets.Price.Min)
+	assert.Equal(t, int64(19900), facets.Price.Max)
+	assert.Len(t, facets.Price.Histogram, 10)
+}
+
+func TestGetRentalById_InCurrency(t *testing.T) {
+	var rental api.Rental
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/rentals/1").
+		Query("currency", "eur").
+		Expect(t).
+		Status(http.StatusOK).
+		End().
+		JSON(&rental)
+
+	assert.Equal(t, api.Price{Currency: &[]string{"EUR"}[0], Day: 15210}, rental.Price)
+}
+
+func TestGetRentals_InCurrency(t *testing.T) {
+	var rentals []api.Rental
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/rentals").
+		Query("ids", "1,11,14,21,24,26").
+		Query("price_max", "9000").
+		Query("sort", "price").
+		Query("currency", "EUR").
+		Expect(t).
+		Status(http.StatusOK).
+		End().
+		JSON(&rentals)
+
+	ids := make([]int, len(rentals))
+	prices := make([]int64, len(rentals))
+	for i, rental := range rentals {
+		assert.Equal(t, "EUR", *rental.Price.Currency)
+		ids[i], prices[i] = rental.Id, rental.Price.Day
+	}
+	assert.Equal(t, []int{26, 24, 11}, ids)
+	assert.Equal(t, []int64{6188, 7560, 8900}, prices)
+}
+
+func TestGetRentals_UnsupportedCurrency(t *testing.T) {
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/rentals").
+		Query("currency", "XYZ").
+		Expect(t).
+		Body(`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "invalid query parameters",
+			"instance": "/v1/rentals",
+			"code": "invalid_request",
+			"errors": [
+				{
+					"field": "currency",
+					"message": "currency 'XYZ' is not supported"
+				}
+			]
+		}`).
+		Status(http.StatusBadRequest).
+		End()
+}
+
 func TestGetRentals_WithQueryParams(t *testing.T) {
 	apitest.New().
 		Handler(echoInstance).
@@ -107,6 +303,7 @@ func TestGetRentals_WithQueryParams(t *testing.T) {
 		Expect(t).
 		Body(`[
 			{
+				"created": "2021-11-29T22:42:06.478595Z",
 				"description": "ultrices consectetur torquent posuere phasellus urna faucibus convallis fusce sem felis malesuada luctus diam hendrerit fermentum ante nisl potenti nam laoreet netus est erat mi",
 				"id": 1,
 				"length": 15,
@@ -122,19 +319,23 @@ func TestGetRentals_WithQueryParams(t *testing.T) {
 				"model": "Bay Window",
 				"name": "'Abaco' VW Bay Window: Westfalia Pop-top",
 				"price": {
+					"currency": "USD",
 					"day": 16900
 				},
 				"primary_image_url": "https://res.cloudinary.com/outdoorsy/image/upload/v1528586451/p/rentals/4447/images/yd7txtw4hnkjvklg8edg.jpg",
 				"sleeps": 4,
 				"type": "camper-van",
+				"updated": "2021-11-29T22:42:06.478595Z",
 				"user": {
 					"first_name": "John",
 					"id": 1,
 					"last_name": "Smith"
 				},
-				"year": 1978
+				"year": 1978,
+				"distance": 0
 			},
 			{
+				"created": "2021-11-29T22:42:06.478595Z",
 				"description": "urna iaculis sed ut porttitor mollis ante cubilia ad felis duis varius mollis nascetur metus faucibus ligula ultricies in faucibus morbi imperdiet auctor morbi torquent",
 				"id": 3,
 				"length": 16,
@@ -150,19 +351,530 @@ func TestGetRentals_WithQueryParams(t *testing.T) {
 				"model": "Westfalia",
 				"name": "1984 Volkswagen Westfalia",
 				"price": {
+					"currency": "USD",
 					"day": 18000
 				},
 				"primary_image_url": "https://res.cloudinary.com/outdoorsy/image/upload/v1504395813/p/rentals/21399/images/nxtwdubpapgpmuc65pd1.jpg",
 				"sleeps": 4,
 				"type": "camper-van",
+				"updated": "2021-11-29T22:42:06.478595Z",
 				"user": {
 					"first_name": "Barry",
 					"id": 3,
 					"last_name": "Martin"
 				},
-				"year": 1984
+				"year": 1984,
+				"distance": 67.41
+			}
+		]`).
+		Header("X-Total-Count", "5").
+		Header("Link", `</v1/rentals?limit=3&near=33.64%2C-117.93&offset=0&price_max=75000&price_min=9000&sort=price>; rel="first", `+
+			`</v1/rentals?limit=3&near=33.64%2C-117.93&offset=0&price_max=75000&price_min=9000&sort=price>; rel="prev", `+
+			`</v1/rentals?limit=3&near=33.64%2C-117.93&offset=3&price_max=75000&price_min=9000&sort=price>; rel="last"`).
+		Status(http.StatusOK).
+		End()
+}
+
+func TestGetRentals_InvalidQueryParams(t *testing.T) {
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/rentals").
+		Query("near", "33.64").
+		Query("price_min", "abc").
+		Expect(t).
+		Body(`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "invalid request",
+			"instance": "/v1/rentals",
+			"code": "invalid_request",
+			"errors": [
+				{
+					"field": "price_min",
+					"message": "must be an integer"
+				},
+				{
+					"field": "near",
+					"message": "must have exactly 2 items"
+				}
+			]
+		}`).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func TestSearchRentals_WithinPolygon(t *testing.T) {
+	apitest.New().
+		Handler(echoInstance).
+		Post("/v1/rentals/search").
+		Query("price_max", "5900").
+		JSON(`{
+			"geometry": {
+				"type": "Polygon",
+				"coordinates": [[[170, 15], [-150, 15], [-150, 25], [170, 25], [170, 15]]]
+			}
+		}`).
+		Expect(t).
+		Body(`[
+			{
+				"created": "2021-11-29T22:42:06.478595Z",
+				"description": "fermentum torquent hac id tortor conubia litora proin sociosqu congue elit ridiculus fames velit viverra faucibus eleifend sagittis etiam aptent sociosqu taciti metus iaculis quam",
+				"id": 9,
+				"length": 13,
+				"location": {
+					"city": "Kahului",
+					"country": "US",
+					"lat": 20.88,
+					"lng": -156.45,
+					"state": "HI",
+					"zip": "96732"
+				},
+				"make": "SUBARU IMPREZA 4WD",
+				"model": "SUBARU IMPREZA 4WD",
+				"name": "Maui \"Alani\" camping car SUBARU IMPREZA 4WD  -Cold AC.",
+				"price": {
+					"currency": "USD",
+					"day": 5900
+				},
+				"primary_image_url": "https://res.cloudinary.com/outdoorsy/image/upload/v1538027810/p/rentals/82458/images/bphrohl2r4wxc8wg3v11.jpg",
+				"sleeps": 2,
+				"type": "camper-van",
+				"updated": "2021-11-29T22:42:06.478595Z",
+				"user": {
+					"first_name": "Todd",
+					"id": 4,
+					"last_name": "Edison"
+				},
+				"year": 2003
+			},
+			{
+				"created": "2021-11-29T22:42:06.478595Z",
+				"description": "malesuada neque velit leo pharetra magnis lectus sapien turpis aenean eu blandit per mi accumsan cursus porta conubia per tellus et morbi dictumst et arcu",
+				"id": 12,
+				"length": 17,
+				"location": {
+					"city": "Kihei",
+					"country": "US",
+					"lat": 20.77,
+					"lng": -156.45,
+					"state": "HI",
+					"zip": "96753"
+				},
+				"make": "Ford",
+				"model": "Other",
+				"name": "*ESSENTIAL WORKERS - Pearl - The Maui Camping Cruiser",
+				"price": {
+					"currency": "USD",
+					"day": 3000
+				},
+				"primary_image_url": "https://res.cloudinary.com/outdoorsy/image/upload/v1550269521/p/rentals/108507/images/zlruuz6ll72taorfwjs1.jpg",
+				"sleeps": 2,
+				"type": "camper-van",
+				"updated": "2021-11-29T22:42:06.478595Z",
+				"user": {
+					"first_name": "Jane",
+					"id": 2,
+					"last_name": "Doe"
+				},
+				"year": 2010
+			}
+		]`).
+		Status(http.StatusOK).
+		End()
+}
+
+func TestCreatePatchDeleteRental(t *testing.T) {
+	var created api.Rental
+	apitest.New().
+		Handler(echoInstance).
+		Post("/v1/rentals").
+		JSON(`{
+			"user_id": 3,
+			"name": "Test Rental",
+			"description": "Test Description",
+			"type": "camper-van",
+			"make": "Volkswagen",
+			"model": "Westfalia",
+			"year": 1984,
+			"length": 16,
+			"sleeps": 4,
+			"primary_image_url": "https://example.com/image.jpg",
+			"price": {"day": 18000},
+			"location": {"city": "San Diego", "state": "CA", "zip": "92037", "country": "US", "lat": 32.83, "lng": -117.28}
+		}`).
+		Expect(t).
+		Status(http.StatusCreated).
+		End().
+		JSON(&created)
+
+	assert.Equal(t, "Test Rental", created.Name)
+	assert.Equal(t, api.User{Id: 3, FirstName: "Barry", LastName: "Martin"}, created.User)
+	assert.Equal(t, "USD", *created.Price.Currency)
+	assert.NotNil(t, created.Created)
+	rentalPath := fmt.Sprintf("/v1/rentals/%d", created.Id)
+
+	var patched api.Rental
+	apitest.New().
+		Handler(echoInstance).
+		Patch(rentalPath).
+		JSON(`{"price": {"day": 20000}}`).
+		Expect(t).
+		Status(http.StatusOK).
+		End().
+		JSON(&patched)
+
+	assert.Equal(t, int64(20000), patched.Price.Day)
+	assert.Equal(t, created.Name, patched.Name)
+	assert.Equal(t, created.Created, patched.Created)
+
+	apitest.New().
+		Handler(echoInstance).
+		Patch(rentalPath).
+		JSON(`{"year": 1800, "user_id": 404}`).
+		Expect(t).
+		Body(fmt.Sprintf(`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "invalid rental",
+			"instance": "%s",
+			"code": "invalid_request",
+			"errors": [
+				{
+					"field": "year",
+					"message": "must be between 1900 and %d"
+				},
+				{
+					"field": "user_id",
+					"message": "user with id 404 does not exist"
+				}
+			]
+		}`, rentalPath, time.Now().Year()+1)).
+		Status(http.StatusBadRequest).
+		End()
+
+	apitest.New().
+		Handler(echoInstance).
+		Delete(rentalPath).
+		Expect(t).
+		Status(http.StatusNoContent).
+		End()
+
+	apitest.New().
+		Handler(echoInstance).
+		Get(rentalPath).
+		Expect(t).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func TestGetUserById(t *testing.T) {
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/users/4").
+		Expect(t).
+		Body(`{
+			"first_name": "Todd",
+			"id": 4,
+			"last_name": "Edison"
+		}`).
+		Status(http.StatusOK).
+		End()
+}
+
+func TestGetUserRentals_WithQueryParams(t *testing.T) {
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/users/4/rentals").
+		Query("price_max", "6000").
+		Expect(t).
+		Body(`[
+			{
+				"created": "2021-11-29T22:42:06.478595Z",
+				"description": "fermentum torquent hac id tortor conubia litora proin sociosqu congue elit ridiculus fames velit viverra faucibus eleifend sagittis etiam aptent sociosqu taciti metus iaculis quam",
+				"id": 9,
+				"length": 13,
+				"location": {
+					"city": "Kahului",
+					"country": "US",
+					"lat": 20.88,
+					"lng": -156.45,
+					"state": "HI",
+					"zip": "96732"
+				},
+				"make": "SUBARU IMPREZA 4WD",
+				"model": "SUBARU IMPREZA 4WD",
+				"name": "Maui \"Alani\" camping car SUBARU IMPREZA 4WD  -Cold AC.",
+				"price": {
+					"currency": "USD",
+					"day": 5900
+				},
+				"primary_image_url": "https://res.cloudinary.com/outdoorsy/image/upload/v1538027810/p/rentals/82458/images/bphrohl2r4wxc8wg3v11.jpg",
+				"sleeps": 2,
+				"type": "camper-van",
+				"updated": "2021-11-29T22:42:06.478595Z",
+				"user": {
+					"first_name": "Todd",
+					"id": 4,
+					"last_name": "Edison"
+				},
+				"year": 2003
 			}
 		]`).
+		Header("X-Total-Count", "1").
+		Status(http.StatusOK).
+		End()
+}
+
+func TestGetUserRentals_UserNotFound(t *testing.T) {
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/users/404/rentals").
+		Expect(t).
+		Body(`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "user with id 404 not found",
+			"instance": "/v1/users/404/rentals",
+			"code": "user_not_found"
+		}`).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func TestGetRentalAvailability(t *testing.T) {
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/rentals/1/availability").
+		Query("from", "2030-07-01").
+		Query("to", "2030-08-01").
+		Expect(t).
+		Body(`{
+			"available": false,
+			"from": "2030-07-01",
+			"rental_id": 1,
+			"to": "2030-08-01",
+			"unavailable": [
+				{
+					"end_date": "2030-07-08",
+					"reason": "booked",
+					"start_date": "2030-07-01"
+				},
+				{
+					"end_date": "2030-07-22",
+					"reason": "booked",
+					"start_date": "2030-07-20"
+				}
+			]
+		}`).
+		Status(http.StatusOK).
+		End()
+}
+
+func TestGetRentals_AvailableBetweenDates(t *testing.T) {
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/rentals").
+		Query("ids", "1,2,3").
+		Query("start_date", "2030-07-05").
+		Query("end_date", "2030-07-06").
+		Expect(t).
+		Header("X-Total-Count", "1").
+		Status(http.StatusOK).
+		End()
+}
+
+func TestGetBookingById(t *testing.T) {
+	var booking api.Booking
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/bookings/1").
+		Expect(t).
+		Status(http.StatusOK).
+		End().
+		JSON(&booking)
+
+	assert.Equal(t, 1, booking.RentalId)
+	assert.Equal(t, "2030-07-01", booking.StartDate.String())
+	assert.Equal(t, "2030-07-08", booking.EndDate.String())
+	assert.Equal(t, 7, booking.Nights)
+	assert.Equal(t, api.Confirmed, booking.Status)
+	assert.Equal(t, int64(118300), booking.Total)
+	assert.Equal(t, "USD", booking.Currency)
+}
+
+func TestCreateBookingInTheCurrencyOfTheRental(t *testing.T) {
+	var created api.Booking
+	apitest.New().
+		Handler(echoInstance).
+		Post("/v1/rentals/14/bookings").
+		JSON(`{"user_id": 4, "start_date": "2031-03-01", "end_date": "2031-03-03"}`).
+		Expect(t).
+		Status(http.StatusCreated).
+		End().
+		JSON(&created)
+
+	assert.Equal(t, 2, created.Nights)
+	assert.Equal(t, int64(47800), created.Total)
+	assert.Equal(t, "CAD", created.Currency)
+}
+
+func TestCreateConfirmCancelBooking(t *testing.T) {
+	booking := `{"user_id": 4, "start_date": "2030-08-10", "end_date": "2030-08-13"}`
+	var created api.Booking
+	apitest.New().
+		Handler(echoInstance).
+		Post("/v1/rentals/2/bookings").
+		JSON(booking).
+		Expect(t).
+		Status(http.StatusCreated).
+		End().
+		JSON(&created)
+
+	assert.Equal(t, api.Pending, created.Status)
+	assert.Equal(t, 3, created.Nights)
+	assert.Equal(t, int64(45000), created.Total)
+	bookingPath := fmt.Sprintf("/v1/bookings/%d", created.Id)
+
+	apitest.New().
+		Handler(echoInstance).
+		Post("/v1/rentals/2/bookings").
+		JSON(`{"user_id": 5, "start_date": "2030-08-12", "end_date": "2030-08-15"}`).
+		Expect(t).
+		Body(`{
+			"type": "about:blank",
+			"title": "Conflict",
+			"status": 409,
+			"detail": "rental with id 2 is not available from 2030-08-12 to 2030-08-15",
+			"instance": "/v1/rentals/2/bookings",
+			"code": "rental_unavailable"
+		}`).
+		Status(http.StatusConflict).
+		End()
+
+	var confirmed api.Booking
+	apitest.New().
+		Handler(echoInstance).
+		Patch(bookingPath).
+		JSON(`{"status": "confirmed"}`).
+		Expect(t).
+		Status(http.StatusOK).
+		End().
+		JSON(&confirmed)
+
+	assert.Equal(t, api.Confirmed, confirmed.Status)
+
+	var cancelled api.Booking
+	apitest.New().
+		Handler(echoInstance).
+		Patch(bookingPath).
+		JSON(`{"status": "cancelled"}`).
+		Expect(t).
 		Status(http.StatusOK).
+		End().
+		JSON(&cancelled)
+
+	assert.Equal(t, api.Cancelled, cancelled.Status)
+
+	apitest.New().
+		Handler(echoInstance).
+		Patch(bookingPath).
+		JSON(`{"status": "confirmed"}`).
+		Expect(t).
+		Body(fmt.Sprintf(`{
+			"type": "about:blank",
+			"title": "Conflict",
+			"status": 409,
+			"detail": "booking with id %d can not change from cancelled to confirmed",
+			"instance": "%s",
+			"code": "booking_status_conflict"
+		}`, created.Id, bookingPath)).
+		Status(http.StatusConflict).
+		End()
+
+	apitest.New().
+		Handler(echoInstance).
+		Post("/v1/rentals/2/bookings").
+		JSON(booking).
+		Expect(t).
+		Status(http.StatusCreated).
+		End()
+}
+
+func TestGetRentalQuote(t *testing.T) {
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/rentals/1/quote").
+		Query("start", "2030-08-01").
+		Query("end", "2030-08-04").
+		Query("guests", "2").
+		Expect(t).
+		Body(`{
+			"currency": "USD",
+			"end": "2030-08-04",
+			"guests": 2,
+			"line_items": [
+				{
+					"amount": 16900,
+					"description": "Nightly rate",
+					"quantity": 1,
+					"type": "nightly",
+					"unit_amount": 16900
+				},
+				{
+					"amount": 39800,
+					"description": "Weekend rate",
+					"quantity": 2,
+					"type": "weekend",
+					"unit_amount": 19900
+				},
+				{
+					"amount": 7500,
+					"description": "Cleaning fee",
+					"quantity": 1,
+					"type": "cleaning_fee",
+					"unit_amount": 7500
+				},
+				{
+					"amount": 4655,
+					"description": "CA tax of 7.25%",
+					"quantity": 1,
+					"type": "tax",
+					"unit_amount": 4655
+				}
+			],
+			"nights": 3,
+			"rental_id": 1,
+			"start": "2030-08-01",
+			"total": 68855
+		}`).
+		Status(http.StatusOK).
+		End()
+}
+
+func TestGetRentalQuote_BelowMinimumNights(t *testing.T) {
+	apitest.New().
+		Handler(echoInstance).
+		Get("/v1/rentals/1/quote").
+		Query("start", "2030-08-01").
+		Query("end", "2030-08-02").
+		Expect(t).
+		Body(`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "invalid quote",
+			"instance": "/v1/rentals/1/quote",
+			"code": "invalid_request",
+			"errors": [
+				{
+					"field": "end",
+					"message": "must be at least 2 nights after start"
+				}
+			]
+		}`).
+		Status(http.StatusBadRequest).
 		End()
 }
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/toshko07/outdoorsy-challenge/api"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/services"
+)
+
+type BookingsController struct {
+	BookingsService services.Bookings
+}
+
+func NewBookingsController(bookingsService services.Bookings) *BookingsController {
+	return &BookingsController{
+		BookingsService: bookingsService,
+	}
+}
+
+// Get Booking by id
+func (c *BookingsController) GetBooking(ctx context.Context, request api.GetBookingRequestObject) (api.GetBookingResponseObject, error) {
+	booking, err := c.BookingsService.GetBooking(ctx, request.BookingId)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetBooking200JSONResponse(createBookingResponse(*booking)), nil
+}
+
+// Create Booking of a Rental by the rental id
+func (c *BookingsController) CreateBooking(ctx context.Context, request api.CreateBookingRequestObject) (api.CreateBookingResponseObject, error) {
+	booking := consumeBookingInput(*request.Body)
+	booking.RentalId = request.RentalId
+	created, err := c.BookingsService.CreateBooking(ctx, booking)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.CreateBooking201JSONResponse{
+		Body:    createBookingResponse(*created),
+		Headers: api.CreateBooking201ResponseHeaders{Location: fmt.Sprintf("/v1/bookings/%d", created.Id)},
+	}, nil
+}
+
+// Update the status of a Booking by id
+func (c *BookingsController) UpdateBookingStatus(ctx context.Context, request api.UpdateBookingStatusRequestObject) (api.UpdateBookingStatusResponseObject, error) {
+	booking, err := c.BookingsService.UpdateBookingStatus(ctx, request.BookingId, string(request.Body.Status))
+	if err != nil {
+		return nil, err
+	}
+
+	return api.UpdateBookingStatus200JSONResponse(createBookingResponse(*booking)), nil
+}
+
+func createBookingResponse(booking models.Booking) api.Booking {
+	return api.Booking{
+		Id:        booking.Id,
+		RentalId:  booking.RentalId,
+		UserId:    booking.UserId,
+		StartDate: openapi_types.Date{Time: booking.Start},
+		EndDate:   openapi_types.Date{Time: booking.End},
+		Nights:    booking.Days(),
+		Status:    api.BookingStatus(booking.Status),
+		Total:     booking.Total,
+		Currency:  booking.Currency,
+		Created:   booking.Created,
+		Updated:   booking.Updated,
+	}
+}
+
+func consumeBookingInput(input api.BookingInput) models.Booking {
+	return models.Booking{
+		UserId: input.UserId,
+		DateRange: models.DateRange{
+			Start: input.StartDate.Time,
+			End:   input.EndDate.Time,
+		},
+	}
+}
@@ -0,0 +1,241 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+const bookingInputJSON = `{"user_id": 5, "start_date": "2030-08-01", "end_date": "2030-08-04"}`
+
+func storedBooking(id int, status string) *models.Booking {
+	return &models.Booking{
+		Id:       id,
+		RentalId: 1,
+		UserId:   5,
+		DateRange: models.DateRange{
+			Start: time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2030, 8, 4, 0, 0, 0, 0, time.UTC),
+		},
+		Status:   status,
+		Total:    50700,
+		Currency: "USD",
+		Created:  time.Date(2030, 6, 1, 10, 0, 0, 0, time.UTC),
+		Updated:  time.Date(2030, 6, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestBookings_GetBooking(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		id                      string
+		expectedServiceResponse *models.Booking
+		expectedServiceError    error
+		expectedResponse        string
+		expectedStatusCode      int
+	}{
+		{
+			name:                    "Get an existing booking",
+			id:                      "4",
+			expectedServiceResponse: storedBooking(4, models.BookingStatusPending),
+			expectedResponse:        "{\"created\":\"2030-06-01T10:00:00Z\",\"currency\":\"USD\",\"end_date\":\"2030-08-04\",\"id\":4,\"nights\":3,\"rental_id\":1,\"start_date\":\"2030-08-01\",\"status\":\"pending\",\"total\":50700,\"updated\":\"2030-06-01T10:00:00Z\",\"user_id\":5}\n",
+			expectedStatusCode:      http.StatusOK,
+		},
+		{
+			name:                 "Get a non-existing booking",
+			id:                   "404",
+			expectedServiceError: models.NewNotFoundError(models.BookingNotFoundCode, "booking with id 404 not found"),
+			expectedResponse:     "{\"code\":\"booking_not_found\",\"detail\":\"booking with id 404 not found\",\"instance\":\"/v1/bookings/404\",\"status\":404,\"title\":\"Not Found\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusNotFound,
+		},
+		{
+			name:               "Id is not a number",
+			id:                 "abc",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"booking_id\",\"message\":\"must be an integer\"}],\"instance\":\"/v1/bookings/abc\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockBookings(ctrl)
+			if tc.expectedServiceResponse != nil || tc.expectedServiceError != nil {
+				id, _ := strconv.Atoi(tc.id)
+				service.EXPECT().GetBooking(gomock.Any(), id).Return(tc.expectedServiceResponse, tc.expectedServiceError)
+			}
+			server := &Server{BookingsController: NewBookingsController(service)}
+			req := httptest.NewRequest(http.MethodGet, "/v1/bookings/"+tc.id, nil)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestBookings_CreateBooking(t *testing.T) {
+	requested := models.Booking{
+		RentalId: 1,
+		UserId:   5,
+		DateRange: models.DateRange{
+			Start: time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2030, 8, 4, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	pricedInCAD := storedBooking(4, models.BookingStatusPending)
+	pricedInCAD.Total = 71700
+	pricedInCAD.Currency = "CAD"
+
+	testCases := []struct {
+		name                    string
+		rentalId                string
+		body                    string
+		expectedServiceResponse *models.Booking
+		expectedServiceError    error
+		expectedResponse        string
+		expectedLocation        string
+		expectedStatusCode      int
+	}{
+		{
+			name:                    "Book a rental",
+			rentalId:                "1",
+			body:                    bookingInputJSON,
+			expectedServiceResponse: storedBooking(4, models.BookingStatusPending),
+			expectedResponse:        "{\"created\":\"2030-06-01T10:00:00Z\",\"currency\":\"USD\",\"end_date\":\"2030-08-04\",\"id\":4,\"nights\":3,\"rental_id\":1,\"start_date\":\"2030-08-01\",\"status\":\"pending\",\"total\":50700,\"updated\":\"2030-06-01T10:00:00Z\",\"user_id\":5}\n",
+			expectedLocation:        "/v1/bookings/4",
+			expectedStatusCode:      http.StatusCreated,
+		},
+		{
+			name:                    "Book a rental priced in another currency",
+			rentalId:                "1",
+			body:                    bookingInputJSON,
+			expectedServiceResponse: pricedInCAD,
+			expectedResponse:        "{\"created\":\"2030-06-01T10:00:00Z\",\"currency\":\"CAD\",\"end_date\":\"2030-08-04\",\"id\":4,\"nights\":3,\"rental_id\":1,\"start_date\":\"2030-08-01\",\"status\":\"pending\",\"total\":71700,\"updated\":\"2030-06-01T10:00:00Z\",\"user_id\":5}\n",
+			expectedLocation:        "/v1/bookings/4",
+			expectedStatusCode:      http.StatusCreated,
+		},
+		{
+			name:                 "Book an unavailable rental",
+			rentalId:             "1",
+			body:                 bookingInputJSON,
+			expectedServiceError: models.NewConflictError(models.RentalUnavailableCode, "rental with id 1 is not available from 2030-08-01 to 2030-08-04"),
+			expectedResponse:     "{\"code\":\"rental_unavailable\",\"detail\":\"rental with id 1 is not available from 2030-08-01 to 2030-08-04\",\"instance\":\"/v1/rentals/1/bookings\",\"status\":409,\"title\":\"Conflict\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusConflict,
+		},
+		{
+			name:                 "Book a non-existing rental",
+			rentalId:             "1",
+			body:                 bookingInputJSON,
+			expectedServiceError: models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 1 not found"),
+			expectedResponse:     "{\"code\":\"rental_not_found\",\"detail\":\"rental with id 1 not found\",\"instance\":\"/v1/rentals/1/bookings\",\"status\":404,\"title\":\"Not Found\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusNotFound,
+		},
+		{
+			name:               "Dates are not formatted as YYYY-MM-DD",
+			rentalId:           "1",
+			body:               `{"user_id": 5, "start_date": "08/01/2030", "end_date": "2030-08-04"}`,
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"start_date\",\"message\":\"must be a date formatted as YYYY-MM-DD\"}],\"instance\":\"/v1/rentals/1/bookings\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Rental id is not a number",
+			rentalId:           "abc",
+			body:               bookingInputJSON,
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"rental_id\",\"message\":\"must be an integer\"}],\"instance\":\"/v1/rentals/abc/bookings\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockBookings(ctrl)
+			if tc.expectedServiceResponse != nil || tc.expectedServiceError != nil {
+				service.EXPECT().CreateBooking(gomock.Any(), requested).Return(tc.expectedServiceResponse, tc.expectedServiceError)
+			}
+			server := &Server{BookingsController: NewBookingsController(service)}
+			req := httptest.NewRequest(http.MethodPost, "/v1/rentals/"+tc.rentalId+"/bookings", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+			assert.Equal(t, tc.expectedLocation, rec.Header().Get(echo.HeaderLocation))
+		})
+	}
+}
+
+func TestBookings_UpdateBookingStatus(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		body                    string
+		expectedStatus          string
+		expectedServiceResponse *models.Booking
+		expectedServiceError    error
+		expectedResponse        string
+		expectedStatusCode      int
+	}{
+		{
+			name:                    "Confirm a booking",
+			body:                    `{"status": "confirmed"}`,
+			expectedStatus:          models.BookingStatusConfirmed,
+			expectedServiceResponse: storedBooking(4, models.BookingStatusConfirmed),
+			expectedResponse:        "{\"created\":\"2030-06-01T10:00:00Z\",\"currency\":\"USD\",\"end_date\":\"2030-08-04\",\"id\":4,\"nights\":3,\"rental_id\":1,\"start_date\":\"2030-08-01\",\"status\":\"confirmed\",\"total\":50700,\"updated\":\"2030-06-01T10:00:00Z\",\"user_id\":5}\n",
+			expectedStatusCode:      http.StatusOK,
+		},
+		{
+			name:                 "Confirm a cancelled booking",
+			body:                 `{"status": "confirmed"}`,
+			expectedStatus:       models.BookingStatusConfirmed,
+			expectedServiceError: models.NewConflictError(models.BookingStatusConflictCode, "booking with id 4 can not change from cancelled to confirmed"),
+			expectedResponse:     "{\"code\":\"booking_status_conflict\",\"detail\":\"booking with id 4 can not change from cancelled to confirmed\",\"instance\":\"/v1/bookings/4\",\"status\":409,\"title\":\"Conflict\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusConflict,
+		},
+		{
+			name:               "Body is not JSON",
+			body:               `confirmed`,
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"body\",\"message\":\"must be a JSON object\"}],\"instance\":\"/v1/bookings/4\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockBookings(ctrl)
+			if tc.expectedServiceResponse != nil || tc.expectedServiceError != nil {
+				service.EXPECT().UpdateBookingStatus(gomock.Any(), 4, tc.expectedStatus).Return(tc.expectedServiceResponse, tc.expectedServiceError)
+			}
+			server := &Server{BookingsController: NewBookingsController(service)}
+			req := httptest.NewRequest(http.MethodPatch, "/v1/bookings/4", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
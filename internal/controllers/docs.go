@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/invopop/yaml"
+	"github.com/labstack/echo/v4"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"github.com/toshko07/outdoorsy-challenge/api"
+)
+
+//go:embed docs.html
+var docsPageTemplate string
+
+// DocsController serves the API spec the server is generated from, and a page browsing it with Swagger UI.
+// The spec is the one embedded into the binary, so it always matches the operations it serves.
+type DocsController struct {
+	specJSON []byte
+	specYAML []byte
+	docsPage []byte
+	docsPath string
+}
+
+// docsAssets are the files of the Swagger UI dist the docs page loads. The rest of the dist, like its stock
+// page browsing the petstore example, is not served.
+var docsAssets = []string{
+	"swagger-ui.css",
+	"swagger-ui-bundle.js",
+	"swagger-ui-standalone-preset.js",
+	"favicon-32x32.png",
+	"favicon-16x16.png",
+}
+
+// NewDocsController lists serverURL as the server of the spec and serves the docs page at docsPath.
+// The page loads the spec and its assets under the path of serverURL, which the API may be served behind.
+func NewDocsController(serverURL, docsPath string) (*DocsController, error) {
+	if !strings.HasPrefix(docsPath, "/") {
+		return nil, fmt.Errorf("docs path '%s' must start with /", docsPath)
+	}
+	docsPath = strings.TrimSuffix(docsPath, "/")
+	if docsPath == "" {
+		return nil, errors.New("docs path must not be the root path, which the API is served at")
+	}
+
+	publicURL, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL '%s': %w", serverURL, err)
+	}
+	basePath := strings.TrimSuffix(publicURL.Path, "/")
+
+	spec, err := api.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the API spec: %w", err)
+	}
+	spec.Servers = openapi3.Servers{{URL: serverURL}}
+
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the API spec to JSON: %w", err)
+	}
+	specYAML, err := yaml.JSONToYAML(specJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the API spec to YAML: %w", err)
+	}
+
+	var docsPage bytes.Buffer
+	page := template.Must(template.New("docs").Parse(docsPageTemplate))
+	pageData := map[string]string{
+		"Title":      spec.Info.Title,
+		"AssetsPath": basePath + docsPath,
+		"SpecURL":    basePath + "/openapi.json",
+	}
+	if err := page.Execute(&docsPage, pageData); err != nil {
+		return nil, fmt.Errorf("failed to render the docs page: %w", err)
+	}
+
+	return &DocsController{
+		specJSON: specJSON,
+		specYAML: specYAML,
+		docsPage: docsPage.Bytes(),
+		docsPath: docsPath,
+	}, nil
+}
+
+// RegisterDocsHandlers routes the spec to /openapi.json and /openapi.yaml, and the docs page with its assets
+// to the docs path.
+func RegisterDocsHandlers(e *echo.Echo, c *DocsController) {
+	e.GET("/openapi.json", c.GetOpenAPIJSON)
+	e.GET("/openapi.yaml", c.GetOpenAPIYAML)
+	e.GET(c.docsPath, c.GetDocs)
+	e.GET(c.docsPath+"/", c.GetDocs)
+	for _, asset := range docsAssets {
+		e.GET(c.docsPath+"/"+asset, echo.StaticFileHandler(asset, swaggerFiles.FS))
+	}
+}
+
+// Get the API spec as JSON
+func (c *DocsController) GetOpenAPIJSON(e echo.Context) error {
+	return e.Blob(http.StatusOK, echo.MIMEApplicationJSON, c.specJSON)
+}
+
+// Get the API spec as YAML
+func (c *DocsController) GetOpenAPIYAML(e echo.Context) error {
+	return e.Blob(http.StatusOK, "application/yaml", c.specYAML)
+}
+
+// Get the page browsing the API spec
+func (c *DocsController) GetDocs(e echo.Context) error {
+	return e.HTMLBlob(http.StatusOK, c.docsPage)
+}
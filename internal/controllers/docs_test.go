@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toshko07/outdoorsy-challenge/api"
+)
+
+func TestDocs_GetOpenAPI(t *testing.T) {
+	testCases := []struct {
+		name                string
+		target              string
+		expectedContentType string
+	}{
+		{
+			name:                "Spec as JSON",
+			target:              "/openapi.json",
+			expectedContentType: echo.MIMEApplicationJSON,
+		},
+		{
+			name:                "Spec as YAML",
+			target:              "/openapi.yaml",
+			expectedContentType: "application/yaml",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			expectedSpec, err := api.GetSwagger()
+			require.NoError(t, err)
+			expectedSpec.Servers = openapi3.Servers{{URL: "https://api.outdoorsy.com"}}
+			expectedJSON, err := json.Marshal(expectedSpec)
+			require.NoError(t, err)
+			controller, err := NewDocsController("https://api.outdoorsy.com", "/docs")
+			require.NoError(t, err)
+			e := echo.New()
+			RegisterDocsHandlers(e, controller)
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			rec := httptest.NewRecorder()
+
+			// When
+			e.ServeHTTP(rec, req)
+
+			// Then
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.expectedContentType, rec.Header().Get(echo.HeaderContentType))
+			spec, err := openapi3.NewLoader().LoadFromData(rec.Body.Bytes())
+			require.NoError(t, err)
+			specJSON, err := json.Marshal(spec)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expectedJSON), string(specJSON))
+		})
+	}
+}
+
+func TestDocs_GetDocs(t *testing.T) {
+	testCases := []struct {
+		name                string
+		serverURL           string
+		docsPath            string
+		target              string
+		expectedContentType string
+		expectedContent     string
+		expectedStatusCode  int
+	}{
+		{
+			name:                "Docs page",
+			serverURL:           "http://localhost:8181",
+			docsPath:            "/docs",
+			target:              "/docs",
+			expectedContentType: echo.MIMETextHTMLCharsetUTF8,
+			expectedContent:     "<script src=\"/docs/swagger-ui-bundle.js\" charset=\"UTF-8\"></script>",
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			name:                "Docs page with a trailing slash",
+			serverURL:           "http://localhost:8181",
+			docsPath:            "/api/docs/",
+			target:              "/api/docs/",
+			expectedContentType: echo.MIMETextHTMLCharsetUTF8,
+			expectedContent:     "<link rel=\"stylesheet\" type=\"text/css\" href=\"/api/docs/swagger-ui.css\">",
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			name:                "Docs asset",
+			serverURL:           "http://localhost:8181",
+			docsPath:            "/docs",
+			target:              "/docs/swagger-ui.css",
+			expectedContentType: "text/css; charset=utf-8",
+			expectedContent:     ".swagger-ui",
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			name:                "Docs page behind a path prefix",
+			serverURL:           "https://api.outdoorsy.com/rentals-api/",
+			docsPath:            "/docs",
+			target:              "/docs",
+			expectedContentType: echo.MIMETextHTMLCharsetUTF8,
+			expectedContent:     "url: \"/rentals-api/openapi.json\",",
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			name:                "Stock Swagger UI page",
+			serverURL:           "http://localhost:8181",
+			docsPath:            "/docs",
+			target:              "/docs/index.html",
+			expectedContentType: echo.MIMEApplicationJSONCharsetUTF8,
+			expectedContent:     "Not Found",
+			expectedStatusCode:  http.StatusNotFound,
+		},
+		{
+			name:                "Stock Swagger UI initializer",
+			serverURL:           "http://localhost:8181",
+			docsPath:            "/docs",
+			target:              "/docs/swagger-initializer.js",
+			expectedContentType: echo.MIMEApplicationJSONCharsetUTF8,
+			expectedContent:     "Not Found",
+			expectedStatusCode:  http.StatusNotFound,
+		},
+		{
+			name:                "Unknown docs asset",
+			serverURL:           "http://localhost:8181",
+			docsPath:            "/docs",
+			target:              "/docs/petstore.json",
+			expectedContentType: echo.MIMEApplicationJSONCharsetUTF8,
+			expectedContent:     "Not Found",
+			expectedStatusCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			controller, err := NewDocsController(tc.serverURL, tc.docsPath)
+			require.NoError(t, err)
+			e := echo.New()
+			RegisterDocsHandlers(e, controller)
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			rec := httptest.NewRecorder()
+
+			// When
+			e.ServeHTTP(rec, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedContentType, rec.Header().Get(echo.HeaderContentType))
+			assert.Contains(t, rec.Body.String(), tc.expectedContent)
+		})
+	}
+}
+
+func TestDocs_NewDocsController_InvalidPath(t *testing.T) {
+	testCases := []struct {
+		name          string
+		docsPath      string
+		expectedError string
+	}{
+		{
+			name:          "Relative path",
+			docsPath:      "docs",
+			expectedError: "docs path 'docs' must start with /",
+		},
+		{
+			name:          "Root path",
+			docsPath:      "/",
+			expectedError: "docs path must not be the root path, which the API is served at",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// When
+			controller, err := NewDocsController("http://localhost:8181", tc.docsPath)
+
+			// Then
+			assert.Nil(t, controller)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
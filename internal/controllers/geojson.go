@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/toshko07/outdoorsy-challenge/api"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+// consumeGeometry converts a GeoJSON Polygon or MultiPolygon into polygons, validating every ring and position.
+func consumeGeometry(geometry api.GeoJSONGeometry) ([]models.Polygon, error) {
+	var coordinates [][][][]float64
+	var err error
+	switch geometry.Type {
+	case api.Polygon:
+		var polygon [][][]float64
+		err = json.Unmarshal(geometry.Coordinates, &polygon)
+		coordinates = [][][][]float64{polygon}
+	case api.MultiPolygon:
+		err = json.Unmarshal(geometry.Coordinates, &coordinates)
+	default:
+		return nil, models.NewBadRequestError("invalid geometry", models.FieldError{
+			Field: "geometry.type",
+			Msg:   fmt.Sprintf("must be one of '%s', '%s'", api.Polygon, api.MultiPolygon),
+		})
+	}
+	if err != nil || len(coordinates) == 0 {
+		return nil, models.NewBadRequestError("invalid geometry", models.FieldError{
+			Field: "geometry.coordinates",
+			Msg:   fmt.Sprintf("must be the coordinates of a GeoJSON %s", geometry.Type),
+		})
+	}
+
+	polygons := make([]models.Polygon, len(coordinates))
+	for i, rings := range coordinates {
+		polygon, msg := consumePolygon(rings)
+		if msg != "" {
+			return nil, models.NewBadRequestError("invalid geometry", models.FieldError{Field: "geometry.coordinates", Msg: msg})
+		}
+		polygons[i] = polygon
+	}
+
+	return polygons, nil
+}
+
+// consumePolygon converts the rings of a single polygon, returning a message describing the first invalid ring.
+func consumePolygon(rings [][][]float64) (models.Polygon, string) {
+	if len(rings) == 0 {
+		return nil, "polygons must have an exterior ring"
+	}
+
+	polygon := make(models.Polygon, len(rings))
+	for i, ring := range rings {
+		if len(ring) < 4 {
+			return nil, "rings must have at least 4 positions"
+		}
+
+		polygon[i] = make([]models.Position, len(ring))
+		for j, position := range ring {
+			// A third altitude value is allowed by GeoJSON and ignored.
+			if len(position) < 2 {
+				return nil, "positions must be [lng, lat] pairs"
+			}
+			if position[0] < -180 || position[0] > 180 || position[1] < -90 || position[1] > 90 {
+				return nil, "positions must have a longitude between -180 and 180 and a latitude between -90 and 90"
+			}
+			polygon[i][j] = models.Position{position[0], position[1]}
+		}
+
+		if polygon[i][0] != polygon[i][len(ring)-1] {
+			return nil, "rings must be closed, with the same first and last position"
+		}
+	}
+
+	return polygon, ""
+}
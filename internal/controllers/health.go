@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/services"
+)
+
+// HealthController serves the probes of the orchestrator, which are not part of the API spec.
+type HealthController struct {
+	healthService services.Health
+}
+
+func NewHealthController(healthService services.Health) *HealthController {
+	return &HealthController{healthService: healthService}
+}
+
+// RegisterHealthHandlers routes the liveness probe to /healthz and the readiness probe to /readyz.
+func RegisterHealthHandlers(e *echo.Echo, c *HealthController) {
+	e.GET("/healthz", c.GetLiveness)
+	e.GET("/readyz", c.GetReadiness)
+}
+
+type healthResponse struct {
+	Status       string                              `json:"status"`
+	Dependencies map[string]dependencyHealthResponse `json:"dependencies,omitempty"`
+}
+
+type dependencyHealthResponse struct {
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Get whether the process is alive, which it is as long as it responds
+func (c *HealthController) GetLiveness(e echo.Context) error {
+	return e.JSON(http.StatusOK, healthResponse{Status: models.HealthStatusUp})
+}
+
+// Get whether the API is ready to serve requests, along with the health of each of its dependencies
+func (c *HealthController) GetReadiness(e echo.Context) error {
+	health := c.healthService.Readiness(e.Request().Context())
+
+	response := healthResponse{
+		Status:       health.Status,
+		Dependencies: make(map[string]dependencyHealthResponse, len(health.Dependencies)),
+	}
+	for name, dependency := range health.Dependencies {
+		response.Dependencies[name] = dependencyHealthResponse{Status: dependency.Status, Detail: dependency.Detail}
+	}
+
+	status := http.StatusOK
+	if health.Status != models.HealthStatusUp {
+		status = http.StatusServiceUnavailable
+	}
+
+	return e.JSON(status, response)
+}
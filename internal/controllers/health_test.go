@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHealth_GetLiveness(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	e := echo.New()
+	RegisterHealthHandlers(e, NewHealthController(services.NewMockHealth(ctrl)))
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"status\":\"up\"}\n", rec.Body.String())
+}
+
+func TestHealth_GetReadiness(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		expectedServiceHealth models.Health
+		expectedResponse      string
+		expectedStatusCode    int
+	}{
+		{
+			name: "Ready",
+			expectedServiceHealth: models.Health{
+				Status: models.HealthStatusUp,
+				Dependencies: map[string]models.DependencyHealth{
+					models.HealthCheckShutdown:   {Status: models.HealthStatusUp},
+					models.HealthCheckDatabase:   {Status: models.HealthStatusUp},
+					models.HealthCheckMigrations: {Status: models.HealthStatusUp},
+				},
+			},
+			expectedResponse:   "{\"status\":\"up\",\"dependencies\":{\"database\":{\"status\":\"up\"},\"migrations\":{\"status\":\"up\"},\"shutdown\":{\"status\":\"up\"}}}\n",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Not ready",
+			expectedServiceHealth: models.Health{
+				Status: models.HealthStatusDown,
+				Dependencies: map[string]models.DependencyHealth{
+					models.HealthCheckShutdown:   {Status: models.HealthStatusDown, Detail: "shutting down"},
+					models.HealthCheckDatabase:   {Status: models.HealthStatusDown, Detail: "timed out"},
+					models.HealthCheckMigrations: {Status: models.HealthStatusDown, Detail: "schema version is 5, expected at least 6"},
+				},
+			},
+			expectedResponse:   "{\"status\":\"down\",\"dependencies\":{\"database\":{\"status\":\"down\",\"detail\":\"timed out\"},\"migrations\":{\"status\":\"down\",\"detail\":\"schema version is 5, expected at least 6\"},\"shutdown\":{\"status\":\"down\",\"detail\":\"shutting down\"}}}\n",
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockHealth(ctrl)
+			service.EXPECT().Readiness(gomock.Any()).Return(tc.expectedServiceHealth)
+			e := echo.New()
+			RegisterHealthHandlers(e, NewHealthController(service))
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			rec := httptest.NewRecorder()
+
+			// When
+			e.ServeHTTP(rec, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
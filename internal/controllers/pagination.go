@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+const (
+	headerTotalCount = "X-Total-Count"
+	headerNextCursor = "X-Next-Cursor"
+	headerLink       = "Link"
+)
+
+// paginationHeader returns the total count, the next cursor and the RFC 8288 links of a page.
+// Pages of an offset get first, prev, next and last links, while pages of a cursor only
+// know the first page and the one after them.
+func paginationHeader(requestURL *url.URL, page models.RentalsPage, cursor bool) http.Header {
+	header := http.Header{}
+	header.Set(headerTotalCount, strconv.Itoa(page.Total))
+	if page.NextCursor != nil {
+		header.Set(headerNextCursor, page.NextCursor.Encode())
+	}
+
+	if cursor {
+		links := []string{cursorLink(requestURL, page.Limit, "", "first")}
+		if page.NextCursor != nil {
+			links = append(links, cursorLink(requestURL, page.Limit, page.NextCursor.Encode(), "next"))
+		}
+		header.Set(headerLink, strings.Join(links, ", "))
+		return header
+	}
+
+	links := []string{pageLink(requestURL, page.Limit, 0, "first")}
+	if page.Offset > 0 {
+		prev := page.Offset - page.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, pageLink(requestURL, page.Limit, prev, "prev"))
+	}
+	if page.Offset+page.Limit < page.Total {
+		links = append(links, pageLink(requestURL, page.Limit, page.Offset+page.Limit, "next"))
+	}
+	last := 0
+	if page.Total > 0 {
+		last = (page.Total - 1) / page.Limit * page.Limit
+	}
+	links = append(links, pageLink(requestURL, page.Limit, last, "last"))
+
+	header.Set(headerLink, strings.Join(links, ", "))
+	return header
+}
+
+// pageLink formats a link to the request URL with the limit and offset of another page.
+func pageLink(requestURL *url.URL, limit, offset int, rel string) string {
+	query := requestURL.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+
+	return fmt.Sprintf(`<%s?%s>; rel="%s"`, requestURL.Path, query.Encode(), rel)
+}
+
+// cursorLink formats a link to the request URL continuing after the cursor, or to the first page without one.
+func cursorLink(requestURL *url.URL, limit int, cursor string, rel string) string {
+	query := requestURL.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Del("offset")
+	query.Del("cursor")
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	return fmt.Sprintf(`<%s?%s>; rel="%s"`, requestURL.Path, query.Encode(), rel)
+}
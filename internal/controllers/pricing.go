@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/toshko07/outdoorsy-challenge/api"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/services"
+)
+
+type PricingController struct {
+	PricingService services.Pricing
+}
+
+func NewPricingController(pricingService services.Pricing) *PricingController {
+	return &PricingController{
+		PricingService: pricingService,
+	}
+}
+
+// Get a price Quote for a stay at a Rental by the rental id
+func (c *PricingController) GetRentalQuote(ctx context.Context, request api.GetRentalQuoteRequestObject) (api.GetRentalQuoteResponseObject, error) {
+	dates, guests, err := consumeQuoteParams(request.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := c.PricingService.Quote(ctx, request.RentalId, dates, guests)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetRentalQuote200JSONResponse(createQuoteResponse(*quote)), nil
+}
+
+func createQuoteResponse(quote models.Quote) api.Quote {
+	lineItems := make([]api.LineItem, len(quote.LineItems))
+	for i, item := range quote.LineItems {
+		lineItems[i] = api.LineItem{
+			Type:        api.LineItemType(item.Type),
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitAmount:  item.UnitAmount,
+			Amount:      item.Amount,
+		}
+	}
+
+	return api.Quote{
+		RentalId:  quote.RentalId,
+		Start:     openapi_types.Date{Time: quote.Start},
+		End:       openapi_types.Date{Time: quote.End},
+		Nights:    quote.Days(),
+		Guests:    quote.Guests,
+		LineItems: lineItems,
+		Total:     quote.Total,
+		Currency:  quote.Currency,
+	}
+}
+
+// consumeQuoteParams reads the start and end of the stay and the number of guests, 1 when missing.
+func consumeQuoteParams(params api.GetRentalQuoteParams) (models.DateRange, int, error) {
+	var errs fieldErrors
+	dates := models.DateRange{Start: params.Start.Time, End: params.End.Time}
+	switch {
+	case !dates.End.After(dates.Start):
+		errs.add("end", "must be after start")
+	case dates.Days() > models.MaxAvailabilityDays:
+		errs.add("end", fmt.Sprintf("must not be more than %d days after start", models.MaxAvailabilityDays))
+	}
+
+	guests := 1
+	if params.Guests != nil {
+		guests = *params.Guests
+	}
+
+	return dates, guests, errs.err()
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPricing_GetQuote(t *testing.T) {
+	dates := models.DateRange{Start: time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 8, 4, 0, 0, 0, 0, time.UTC)}
+	quote := &models.Quote{
+		RentalId:  1,
+		DateRange: dates,
+		Guests:    2,
+		Currency:  "USD",
+		LineItems: []models.LineItem{
+			{Type: models.LineItemNightly, Description: "Nightly rate", Quantity: 3, UnitAmount: 16900, Amount: 50700},
+			{Type: models.LineItemTax, Description: "CA tax of 7.25%", Quantity: 1, UnitAmount: 3676, Amount: 3676},
+		},
+		Total: 54376,
+	}
+
+	testCases := []struct {
+		name                    string
+		id                      string
+		query                   string
+		expectedDates           models.DateRange
+		expectedGuests          int
+		expectedServiceResponse *models.Quote
+		expectedServiceError    error
+		expectedResponse        string
+		expectedStatusCode      int
+	}{
+		{
+			name:                    "Quote a stay",
+			id:                      "1",
+			query:                   "start=2030-08-01&end=2030-08-04&guests=2",
+			expectedDates:           dates,
+			expectedGuests:          2,
+			expectedServiceResponse: quote,
+			expectedResponse:        "{\"currency\":\"USD\",\"end\":\"2030-08-04\",\"guests\":2,\"line_items\":[{\"amount\":50700,\"description\":\"Nightly rate\",\"quantity\":3,\"type\":\"nightly\",\"unit_amount\":16900},{\"amount\":3676,\"description\":\"CA tax of 7.25%\",\"quantity\":1,\"type\":\"tax\",\"unit_amount\":3676}],\"nights\":3,\"rental_id\":1,\"start\":\"2030-08-01\",\"total\":54376}\n",
+			expectedStatusCode:      http.StatusOK,
+		},
+		{
+			name:                 "Quote a stay of one guest by default",
+			id:                   "1",
+			query:                "start=2030-08-01&end=2030-08-04",
+			expectedDates:        dates,
+			expectedGuests:       1,
+			expectedServiceError: models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 1 not found"),
+			expectedResponse:     "{\"code\":\"rental_not_found\",\"detail\":\"rental with id 1 not found\",\"instance\":\"/v1/rentals/1/quote\",\"status\":404,\"title\":\"Not Found\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusNotFound,
+		},
+		{
+			name:                 "Stay shorter than the minimum nights",
+			id:                   "1",
+			query:                "start=2030-08-01&end=2030-08-02",
+			expectedDates:        models.DateRange{Start: dates.Start, End: time.Date(2030, 8, 2, 0, 0, 0, 0, time.UTC)},
+			expectedGuests:       1,
+			expectedServiceError: models.NewBadRequestError("invalid quote", models.FieldError{Field: "end", Msg: "must be at least 2 nights after start"}),
+			expectedResponse:     "{\"code\":\"invalid_request\",\"detail\":\"invalid quote\",\"errors\":[{\"field\":\"end\",\"message\":\"must be at least 2 nights after start\"}],\"instance\":\"/v1/rentals/1/quote\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusBadRequest,
+		},
+		{
+			name:               "Missing dates and no guests",
+			id:                 "1",
+			query:              "guests=0",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"start\",\"message\":\"is required\"},{\"field\":\"end\",\"message\":\"is required\"},{\"field\":\"guests\",\"message\":\"must be at least 1\"}],\"instance\":\"/v1/rentals/1/quote\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "End before start",
+			id:                 "1",
+			query:              "start=2030-08-04&end=2030-08-01",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"end\",\"message\":\"must be after start\"}],\"instance\":\"/v1/rentals/1/quote\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Stay longer than a year",
+			id:                 "1",
+			query:              "start=2030-08-01&end=2031-08-03",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"end\",\"message\":\"must not be more than 366 days after start\"}],\"instance\":\"/v1/rentals/1/quote\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Dates are not formatted as YYYY-MM-DD",
+			id:                 "1",
+			query:              "start=08/01/2030&end=2030-08-04&guests=two",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"start\",\"message\":\"must be a date formatted as YYYY-MM-DD\"},{\"field\":\"guests\",\"message\":\"must be an integer\"}],\"instance\":\"/v1/rentals/1/quote\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Id is not a number",
+			id:                 "abc",
+			query:              "start=2030-08-01&end=2030-08-04",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"rental_id\",\"message\":\"must be an integer\"}],\"instance\":\"/v1/rentals/abc/quote\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockPricing(ctrl)
+			if tc.expectedServiceResponse != nil || tc.expectedServiceError != nil {
+				service.EXPECT().Quote(gomock.Any(), 1, tc.expectedDates, tc.expectedGuests).Return(tc.expectedServiceResponse, tc.expectedServiceError)
+			}
+			server := &Server{PricingController: NewPricingController(service)}
+			req := httptest.NewRequest(http.MethodGet, "/v1/rentals/"+tc.id+"/quote?"+tc.query, nil)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+// fieldErrors collects an error for every invalid query parameter, so all of them are reported at once.
+// The validator already rejected the parameters not matching the API spec, these are the checks
+// the spec can not express, like parameters depending on each other.
+type fieldErrors []models.FieldError
+
+func (f *fieldErrors) add(name, msg string) {
+	*f = append(*f, models.FieldError{Field: name, Msg: msg})
+}
+
+func (f fieldErrors) err() error {
+	if len(f) == 0 {
+		return nil
+	}
+
+	return models.NewBadRequestError("invalid query parameters", f...)
+}
+
+// valueOf returns the value of an optional parameter, or the zero value when it is missing.
+func valueOf[T any](param *T) T {
+	if param == nil {
+		var zero T
+		return zero
+	}
+
+	return *param
+}
+
+// trimmed returns the optional parameter without surrounding spaces, reporting it when nothing else is left.
+func (f *fieldErrors) trimmed(name string, param *string) string {
+	if param == nil {
+		return ""
+	}
+
+	value := strings.TrimSpace(*param)
+	if value == "" {
+		f.add(name, "must not be empty")
+	}
+
+	return value
+}
@@ -1,13 +1,16 @@
 package controllers
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
-	"net/url"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/toshko07/outdoorsy-challenge/api"
 	"github.com/toshko07/outdoorsy-challenge/internal/models"
 	"github.com/toshko07/outdoorsy-challenge/internal/services"
@@ -24,38 +27,161 @@ func NewRentalsController(rentalsService services.Rentals) *RentalsController {
 }
 
 // Get Rental by id
-func (c *RentalsController) GetRental(e echo.Context) error {
-	id := e.Param("rental_id")
-	rentalId, err := strconv.Atoi(id)
+func (c *RentalsController) GetRental(ctx context.Context, request api.GetRentalRequestObject) (api.GetRentalResponseObject, error) {
+	rental, err := c.RentalsService.GetRental(ctx, request.RentalId, consumeCurrency(request.Params.Currency))
 	if err != nil {
-		e.Logger().Errorf("failed to convert id '%s' to int: %v", id, err)
-		return handleError(e, models.NewNotFoundError(fmt.Sprintf("rental with id '%s' not found", id)))
+		return nil, err
 	}
 
-	rental, err := c.RentalsService.GetRental(e.Request().Context(), rentalId)
+	return api.GetRental200JSONResponse(createRentalResponse(*rental)), nil
+}
+
+// Create Rental owned by an existing user
+func (c *RentalsController) CreateRental(ctx context.Context, request api.CreateRentalRequestObject) (api.CreateRentalResponseObject, error) {
+	rental, err := c.RentalsService.CreateRental(ctx, consumeRentalInput(*request.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	return api.CreateRental201JSONResponse{
+		Body:    createRentalResponse(*rental),
+		Headers: api.CreateRental201ResponseHeaders{Location: fmt.Sprintf("/v1/rentals/%d", rental.Id)},
+	}, nil
+}
+
+// Update all fields of a Rental by id
+func (c *RentalsController) UpdateRental(ctx context.Context, request api.UpdateRentalRequestObject) (api.UpdateRentalResponseObject, error) {
+	rental := consumeRentalInput(*request.Body)
+	rental.Id = request.RentalId
+	updated, err := c.RentalsService.UpdateRental(ctx, rental)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.UpdateRental200JSONResponse(createRentalResponse(*updated)), nil
+}
+
+// Update the given fields of a Rental by id
+func (c *RentalsController) PatchRental(ctx context.Context, request api.PatchRentalRequestObject) (api.PatchRentalResponseObject, error) {
+	rental, err := c.RentalsService.PatchRental(ctx, request.RentalId, consumeRentalPatch(*request.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	return api.PatchRental200JSONResponse(createRentalResponse(*rental)), nil
+}
+
+// Delete Rental by id
+func (c *RentalsController) DeleteRental(ctx context.Context, request api.DeleteRentalRequestObject) (api.DeleteRentalResponseObject, error) {
+	if err := c.RentalsService.DeleteRental(ctx, request.RentalId); err != nil {
+		return nil, err
+	}
+
+	return api.DeleteRental204Response{}, nil
+}
+
+// Get the Availability of a Rental by id between the from and to dates
+func (c *RentalsController) GetRentalAvailability(ctx context.Context, request api.GetRentalAvailabilityRequestObject) (api.GetRentalAvailabilityResponseObject, error) {
+	dates, err := consumeAvailabilityParams(request.Params, time.Now().UTC())
 	if err != nil {
-		e.Logger().Errorf("failed to get rental with id '%d': %v", rentalId, err)
-		return handleError(e, err)
+		return nil, err
 	}
 
-	return e.JSON(http.StatusOK, createRentalResponse(*rental))
+	availability, err := c.RentalsService.GetAvailability(ctx, request.RentalId, dates)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetRentalAvailability200JSONResponse(createAvailabilityResponse(*availability)), nil
 }
 
 // Get Rentals by query
-func (c *RentalsController) GetRentals(e echo.Context) error {
-	rentalsQueries := consumeQueryParams(e.QueryParams())
-	rentals, err := c.RentalsService.GetRentals(e.Request().Context(), rentalsQueries)
+func (c *RentalsController) GetRentals(ctx context.Context, request api.GetRentalsRequestObject) (api.GetRentalsResponseObject, error) {
+	rentalsQueries, err := consumeQueryParams(request.Params)
 	if err != nil {
-		e.Logger().Errorf("failed to get rentals: %v", err)
-		return handleError(e, err)
+		return nil, err
 	}
 
-	rentalsResponse := make([]api.Rental, len(rentals))
-	for i, rental := range rentals {
+	return c.getRentals(ctx, rentalsQueries)
+}
+
+// Search Rentals within a GeoJSON polygon, narrowed down by the same queries as GetRentals
+func (c *RentalsController) SearchRentals(ctx context.Context, request api.SearchRentalsRequestObject) (api.SearchRentalsResponseObject, error) {
+	rentalsQueries, err := consumeQueryParams(api.GetRentalsParams(request.Params))
+	if err != nil {
+		return nil, err
+	}
+
+	rentalsQueries.Polygons, err = consumeGeometry(request.Body.Geometry)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getRentals(ctx, rentalsQueries)
+}
+
+// Get the facets of the rentals matching the same queries as GetRentals
+func (c *RentalsController) GetRentalFacets(ctx context.Context, request api.GetRentalFacetsRequestObject) (api.GetRentalFacetsResponseObject, error) {
+	rentalsQueries, err := consumeQueryParams(consumeFacetsParams(request.Params))
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := c.RentalsService.GetFacets(ctx, rentalsQueries)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetRentalFacets200JSONResponse(createFacetsResponse(*facets)), nil
+}
+
+func (c *RentalsController) getRentals(ctx context.Context, rentalsQueries models.GetRentalsParams) (rentalsPageResponse, error) {
+	page, err := c.RentalsService.GetRentals(ctx, rentalsQueries)
+	if err != nil {
+		return rentalsPageResponse{}, err
+	}
+
+	return createRentalsPageResponse(ctx, *page, rentalsQueries.After != nil), nil
+}
+
+// rentalsPageResponse responds with the rentals of a page and its pagination headers, to all operations listing rentals.
+type rentalsPageResponse struct {
+	rentals []api.Rental
+	header  http.Header
+}
+
+func createRentalsPageResponse(ctx context.Context, page models.RentalsPage, cursor bool) rentalsPageResponse {
+	rentalsResponse := make([]api.Rental, len(page.Rentals))
+	for i, rental := range page.Rentals {
 		rentalsResponse[i] = createRentalResponse(rental)
 	}
 
-	return e.JSON(http.StatusOK, rentalsResponse)
+	return rentalsPageResponse{
+		rentals: rentalsResponse,
+		header:  paginationHeader(requestURL(ctx), page, cursor),
+	}
+}
+
+func (r rentalsPageResponse) VisitGetRentalsResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r rentalsPageResponse) VisitSearchRentalsResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r rentalsPageResponse) VisitGetUserRentalsResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r rentalsPageResponse) write(w http.ResponseWriter) error {
+	for name, values := range r.header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	w.WriteHeader(http.StatusOK)
+
+	return json.NewEncoder(w).Encode(r.rentals)
 }
 
 func createRentalResponse(rental models.Rental) api.Rental {
@@ -71,7 +197,8 @@ func createRentalResponse(rental models.Rental) api.Rental {
 		Sleeps:          rental.Sleeps,
 		PrimaryImageUrl: rental.PrimaryImageUrl,
 		Price: api.Price{
-			Day: rental.Price.PerDay,
+			Day:      rental.Price.PerDay,
+			Currency: &rental.Price.Currency,
 		},
 		Location: api.Location{
 			City:    rental.Location.City,
@@ -81,68 +208,343 @@ func createRentalResponse(rental models.Rental) api.Rental {
 			Lat:     rental.Location.Lat,
 			Lng:     rental.Location.Lng,
 		},
-		User: api.User{
-			Id:        rental.User.Id,
-			FirstName: rental.User.FirstName,
-			LastName:  rental.User.LastName,
+		User:     createUserResponse(rental.User),
+		Distance: roundDistance(rental.Distance),
+		Created:  optionalTime(rental.Created),
+		Updated:  optionalTime(rental.Updated),
+	}
+}
+
+func createFacetsResponse(facets models.RentalFacets) api.RentalFacets {
+	response := api.RentalFacets{
+		Total:     facets.Total,
+		Types:     createFacetCountsResponse(facets.Types),
+		Makes:     createFacetCountsResponse(facets.Makes),
+		States:    createFacetCountsResponse(facets.States),
+		Countries: createFacetCountsResponse(facets.Countries),
+		Sleeps:    createFacetCountsResponse(facets.Sleeps),
+	}
+
+	if facets.Price != nil {
+		histogram := make([]api.PriceBucket, len(facets.Price.Histogram))
+		for i, bucket := range facets.Price.Histogram {
+			histogram[i] = api.PriceBucket{Min: bucket.Min, Max: bucket.Max, Count: bucket.Count}
+		}
+		response.Price = &api.PriceStats{
+			Min:       facets.Price.Min,
+			Max:       facets.Price.Max,
+			P25:       facets.Price.P25,
+			Median:    facets.Price.Median,
+			P75:       facets.Price.P75,
+			Histogram: histogram,
+		}
+	}
+
+	return response
+}
+
+func createFacetCountsResponse(counts []models.FacetCount) []api.FacetCount {
+	response := make([]api.FacetCount, len(counts))
+	for i, count := range counts {
+		response[i] = api.FacetCount{Value: count.Value, Count: count.Count}
+	}
+
+	return response
+}
+
+func createAvailabilityResponse(availability models.Availability) api.Availability {
+	unavailable := make([]api.UnavailablePeriod, len(availability.Unavailable))
+	for i, period := range availability.Unavailable {
+		unavailable[i] = api.UnavailablePeriod{
+			StartDate: openapi_types.Date{Time: period.Start},
+			EndDate:   openapi_types.Date{Time: period.End},
+			Reason:    api.UnavailablePeriodReason(period.Reason),
+		}
+	}
+
+	return api.Availability{
+		RentalId:    availability.RentalId,
+		From:        openapi_types.Date{Time: availability.Start},
+		To:          openapi_types.Date{Time: availability.End},
+		Available:   availability.Available(),
+		Unavailable: unavailable,
+	}
+}
+
+// optionalTime returns nil for the zero time, which rentals have when the timestamp was never set.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func consumeRentalInput(input api.RentalInput) models.Rental {
+	return models.Rental{
+		Name:            input.Name,
+		Description:     input.Description,
+		Type:            input.Type,
+		Make:            input.Make,
+		Model:           input.Model,
+		Year:            input.Year,
+		Length:          input.Length,
+		Sleeps:          input.Sleeps,
+		PrimaryImageUrl: input.PrimaryImageUrl,
+		Price:           consumePrice(input.Price),
+		Location: models.Location{
+			City:    input.Location.City,
+			State:   input.Location.State,
+			Zip:     input.Location.Zip,
+			Country: input.Location.Country,
+			Lat:     input.Location.Lat,
+			Lng:     input.Location.Lng,
+		},
+		User: models.User{
+			Id: input.UserId,
 		},
 	}
 }
 
-func handleError(e echo.Context, err error) error {
-	switch err.(type) {
-	case models.NotFoundError:
-		return e.JSON(http.StatusNotFound, api.Error{
-			Details: err.Error(),
-			Status:  http.StatusNotFound,
-			Title:   "Not Found",
-		})
-	default:
-		return e.JSON(http.StatusInternalServerError, api.Error{
-			Details: "internal server error",
-			Status:  http.StatusInternalServerError,
-			Title:   "Internal Server Error",
-		})
+// consumePrice reads a rental price, in the default currency when none is given.
+func consumePrice(input api.Price) models.Price {
+	price := models.Price{PerDay: input.Day, Currency: models.DefaultCurrency}
+	if input.Currency != nil {
+		price.Currency = *input.Currency
+	}
+
+	return price
+}
+
+func consumeRentalPatch(input api.RentalPatch) models.RentalPatch {
+	patch := models.RentalPatch{
+		UserId:          input.UserId,
+		Name:            input.Name,
+		Description:     input.Description,
+		Type:            input.Type,
+		Make:            input.Make,
+		Model:           input.Model,
+		Year:            input.Year,
+		Length:          input.Length,
+		Sleeps:          input.Sleeps,
+		PrimaryImageUrl: input.PrimaryImageUrl,
+	}
+	if input.Price != nil {
+		patch.PricePerDay = &input.Price.Day
+		patch.Currency = input.Price.Currency
+	}
+	if input.Location != nil {
+		patch.City = input.Location.City
+		patch.State = input.Location.State
+		patch.Zip = input.Location.Zip
+		patch.Country = input.Location.Country
+		patch.Lat = input.Location.Lat
+		patch.Lng = input.Location.Lng
+	}
+
+	return patch
+}
+
+// roundDistance rounds a distance to two decimals, which is precise enough for displaying it.
+func roundDistance(distance *float64) *float64 {
+	if distance == nil {
+		return nil
 	}
+
+	rounded := math.Round(*distance*100) / 100
+	return &rounded
 }
 
-func consumeQueryParams(queryParams url.Values) models.GetRentalsParams {
-	rentalsQueries := models.GetRentalsParams{}
-	if len(queryParams["price_min"]) > 0 {
-		rentalsQueries.PriceMin, _ = strconv.ParseInt(queryParams["price_min"][0], 10, 64)
+// consumeQueryParams reads the queries of the operations listing rentals, all of which accept the parameters
+// of GetRentals.
+func consumeQueryParams(params api.GetRentalsParams) (models.GetRentalsParams, error) {
+	var errs fieldErrors
+	rentalsQueries := models.GetRentalsParams{
+		PriceMin:  valueOf(params.PriceMin),
+		PriceMax:  valueOf(params.PriceMax),
+		YearMin:   valueOf(params.YearMin),
+		YearMax:   valueOf(params.YearMax),
+		SleepsMin: valueOf(params.SleepsMin),
+		LengthMin: valueOf(params.LengthMin),
+		LengthMax: valueOf(params.LengthMax),
+		Limit:     valueOf(params.Limit),
+		Offset:    valueOf(params.Offset),
+		Ids:       valueOf(params.Ids),
+		Near:      valueOf(params.Near),
+		Radius:    valueOf(params.Radius),
+		Unit:      string(valueOf(params.Unit)),
+		Currency:  consumeCurrency(params.Currency),
+	}
+
+	if params.PriceMax != nil && rentalsQueries.PriceMax < rentalsQueries.PriceMin {
+		errs.add("price_max", "must be greater than or equal to price_min")
+	}
+
+	if params.Type != nil {
+		rentalsQueries.Types = make([]string, len(*params.Type))
+		for i, rentalType := range *params.Type {
+			if rentalsQueries.Types[i] = strings.TrimSpace(rentalType); rentalsQueries.Types[i] == "" {
+				errs.add("type", "must be a comma separated list without empty values")
+				break
+			}
+		}
 	}
 
-	if len(queryParams["price_max"]) > 0 {
-		rentalsQueries.PriceMax, _ = strconv.ParseInt(queryParams["price_max"][0], 10, 64)
+	rentalsQueries.Make = errs.trimmed("make", params.Make)
+	rentalsQueries.Model = errs.trimmed("model", params.Model)
+
+	if params.YearMax != nil && rentalsQueries.YearMax < rentalsQueries.YearMin {
+		errs.add("year_max", "must be greater than or equal to year_min")
 	}
 
-	if len(queryParams["limit"]) > 0 {
-		rentalsQueries.Limit, _ = strconv.Atoi(queryParams["limit"][0])
+	if params.LengthMax != nil && rentalsQueries.LengthMax < rentalsQueries.LengthMin {
+		errs.add("length_max", "must be greater than or equal to length_min")
 	}
 
-	if len(queryParams["offset"]) > 0 {
-		rentalsQueries.Offset, _ = strconv.Atoi(queryParams["offset"][0])
+	if near := rentalsQueries.Near; len(near) == 2 {
+		switch {
+		case near[0] < -90 || near[0] > 90:
+			errs.add("near", "latitude must be between -90 and 90")
+		case near[1] < -180 || near[1] > 180:
+			errs.add("near", "longitude must be between -180 and 180")
+		}
+	}
+
+	if params.Radius != nil && len(rentalsQueries.Near) == 0 {
+		errs.add("radius", "requires near")
+	}
+
+	if bbox := valueOf(params.Bbox); len(bbox) == 4 {
+		switch {
+		case bbox[0] < -180 || bbox[0] > 180 || bbox[2] < -180 || bbox[2] > 180:
+			errs.add("bbox", "longitudes must be between -180 and 180")
+		case bbox[1] < -90 || bbox[1] > 90 || bbox[3] < -90 || bbox[3] > 90:
+			errs.add("bbox", "latitudes must be between -90 and 90")
+		case bbox[1] > bbox[3]:
+			errs.add("bbox", "minLat must not be greater than maxLat")
+		default:
+			rentalsQueries.BBox = &models.BoundingBox{MinLng: bbox[0], MinLat: bbox[1], MaxLng: bbox[2], MaxLat: bbox[3]}
+		}
 	}
 
-	if len(queryParams["ids"]) > 0 {
-		ids := strings.Split(queryParams["ids"][0], ",")
-		rentalsQueries.Ids = make([]int, len(ids))
-		for i, id := range ids {
-			rentalsQueries.Ids[i], _ = strconv.Atoi(id)
+	if params.Sort != nil {
+		rentalsQueries.Sort = consumeSort(&errs, *params.Sort)
+		for _, field := range rentalsQueries.Sort {
+			if field.Field == models.SortFieldDistance && len(rentalsQueries.Near) == 0 {
+				errs.add("sort", "sorting by distance requires near")
+			}
 		}
 	}
 
-	if len(queryParams["near"]) > 0 {
-		near := strings.Split(queryParams["near"][0], ",")
-		rentalsQueries.Near = make([]float64, len(near))
-		for i, n := range near {
-			rentalsQueries.Near[i], _ = strconv.ParseFloat(n, 64)
+	rentalsQueries.Query = errs.trimmed("q", params.Q)
+	for _, field := range rentalsQueries.Sort {
+		if field.Field == models.SortFieldRelevance && rentalsQueries.Query == "" {
+			errs.add("sort", "sorting by relevance requires q")
 		}
 	}
 
-	if len(queryParams["sort"]) > 0 {
-		rentalsQueries.Sort = queryParams["sort"][0]
+	switch {
+	case params.StartDate != nil && params.EndDate == nil:
+		errs.add("end_date", "is required with start_date")
+	case params.EndDate != nil && params.StartDate == nil:
+		errs.add("start_date", "is required with end_date")
+	case params.StartDate != nil && params.EndDate != nil:
+		if !params.EndDate.After(params.StartDate.Time) {
+			errs.add("end_date", "must be after start_date")
+		}
+		rentalsQueries.Available = &models.DateRange{Start: params.StartDate.Time, End: params.EndDate.Time}
+	}
+
+	if params.Cursor != nil {
+		cursor, err := models.DecodeCursor(*params.Cursor)
+		switch {
+		case err != nil:
+			errs.add("cursor", "must be a cursor returned by a previous page")
+		case rentalsQueries.Offset > 0:
+			errs.add("cursor", "can not be combined with offset")
+		}
+		rentalsQueries.After = cursor
+	}
+
+	return rentalsQueries, errs.err()
+}
+
+// consumeFacetsParams reads the queries of GetRentalFacets, which are the ones of GetRentals without the pagination.
+func consumeFacetsParams(params api.GetRentalFacetsParams) api.GetRentalsParams {
+	return api.GetRentalsParams{
+		PriceMin:  params.PriceMin,
+		PriceMax:  params.PriceMax,
+		Ids:       params.Ids,
+		Near:      params.Near,
+		Radius:    params.Radius,
+		Unit:      params.Unit,
+		Bbox:      params.Bbox,
+		StartDate: params.StartDate,
+		EndDate:   params.EndDate,
+		Type:      params.Type,
+		Make:      params.Make,
+		Model:     params.Model,
+		YearMin:   params.YearMin,
+		YearMax:   params.YearMax,
+		SleepsMin: params.SleepsMin,
+		LengthMin: params.LengthMin,
+		LengthMax: params.LengthMax,
+		Q:         params.Q,
+		Currency:  params.Currency,
+	}
+}
+
+// defaultAvailabilityDays is the number of days the availability is returned for when no to date is requested.
+const defaultAvailabilityDays = 30
+
+// consumeAvailabilityParams reads the from and to dates of an availability request,
+// from defaults to the day of now and to to defaultAvailabilityDays days after from.
+func consumeAvailabilityParams(params api.GetRentalAvailabilityParams, now time.Time) (models.DateRange, error) {
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if params.From != nil {
+		from = params.From.Time
+	}
+
+	to := from.AddDate(0, 0, defaultAvailabilityDays)
+	if params.To != nil {
+		to = params.To.Time
+	}
+
+	var errs fieldErrors
+	dates := models.DateRange{Start: from, End: to}
+	switch {
+	case !to.After(from):
+		errs.add("to", "must be after from")
+	case dates.Days() > models.MaxAvailabilityDays:
+		errs.add("to", fmt.Sprintf("must not be more than %d days after from", models.MaxAvailabilityDays))
+	}
+
+	return dates, errs.err()
+}
+
+// consumeCurrency reads the optional currency prices are converted into, accepting lower case letters too.
+func consumeCurrency(currency *string) string {
+	return strings.ToUpper(valueOf(currency))
+}
+
+// consumeSort parses sort expressions like "price,-year", where a leading "-" sorts descending.
+func consumeSort(errs *fieldErrors, sort []string) []models.SortField {
+	fields := make([]models.SortField, 0, len(sort))
+	seen := make(map[string]bool, len(sort))
+	for _, expression := range sort {
+		field := models.SortField{Field: strings.TrimPrefix(expression, "-")}
+		field.Desc = field.Field != expression
+
+		switch {
+		case !models.IsSortField(field.Field):
+			errs.add("sort", fmt.Sprintf("unknown sort field '%s'", field.Field))
+		case seen[field.Field]:
+			errs.add("sort", fmt.Sprintf("sort field '%s' is repeated", field.Field))
+		default:
+			seen[field.Field] = true
+			fields = append(fields, field)
+		}
 	}
 
-	return rentalsQueries
+	return fields
 }
@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/api"
 	"github.com/toshko07/outdoorsy-challenge/internal/models"
 	"github.com/toshko07/outdoorsy-challenge/internal/services"
 	"go.uber.org/mock/gomock"
@@ -36,7 +40,7 @@ func TestRentals_GetRental(t *testing.T) {
 				Length:          10,
 				Sleeps:          2,
 				PrimaryImageUrl: "Test Primary Image URL",
-				Price:           models.Price{PerDay: 1},
+				Price:           models.Price{PerDay: 1, Currency: "USD"},
 				Location: models.Location{
 					City:    "Test City",
 					State:   "Test State",
@@ -48,15 +52,15 @@ func TestRentals_GetRental(t *testing.T) {
 				User: models.User{Id: 0, FirstName: "Test First Name", LastName: "Test Last Name"},
 			},
 			expectedServiceError: nil,
-			expectedResponse:     "{\"description\":\"Test Description\",\"id\":1,\"length\":10,\"location\":{\"city\":\"Test City\",\"country\":\"Test Country\",\"lat\":19.99,\"lng\":-19.99,\"state\":\"Test State\",\"zip\":\"Test Zip\"},\"make\":\"Test Make\",\"model\":\"Test Model\",\"name\":\"Test Rental\",\"price\":{\"day\":1},\"primary_image_url\":\"Test Primary Image URL\",\"sleeps\":2,\"type\":\"Test Type\",\"user\":{\"first_name\":\"Test First Name\",\"id\":0,\"last_name\":\"Test Last Name\"},\"year\":2020}\n",
+			expectedResponse:     "{\"description\":\"Test Description\",\"id\":1,\"length\":10,\"location\":{\"city\":\"Test City\",\"country\":\"Test Country\",\"lat\":19.99,\"lng\":-19.99,\"state\":\"Test State\",\"zip\":\"Test Zip\"},\"make\":\"Test Make\",\"model\":\"Test Model\",\"name\":\"Test Rental\",\"price\":{\"currency\":\"USD\",\"day\":1},\"primary_image_url\":\"Test Primary Image URL\",\"sleeps\":2,\"type\":\"Test Type\",\"user\":{\"first_name\":\"Test First Name\",\"id\":0,\"last_name\":\"Test Last Name\"},\"year\":2020}\n",
 			expectedStatusCode:   http.StatusOK,
 		},
 		{
 			name:                    "Get non-existing rental",
 			id:                      404,
 			expectedServiceResponse: nil,
-			expectedServiceError:    models.NewNotFoundError("rental with id '404' not found"),
-			expectedResponse:        "{\"details\":\"rental with id '404' not found\",\"status\":404,\"title\":\"Not Found\"}\n",
+			expectedServiceError:    models.NewNotFoundError(models.RentalNotFoundCode, "rental with id '404' not found"),
+			expectedResponse:        "{\"code\":\"rental_not_found\",\"detail\":\"rental with id '404' not found\",\"instance\":\"/v1/rentals/404\",\"status\":404,\"title\":\"Not Found\",\"type\":\"about:blank\"}\n",
 			expectedStatusCode:      http.StatusNotFound,
 		},
 		{
@@ -64,7 +68,7 @@ func TestRentals_GetRental(t *testing.T) {
 			id:                      500,
 			expectedServiceResponse: nil,
 			expectedServiceError:    fmt.Errorf("test error"),
-			expectedResponse:        "{\"details\":\"internal server error\",\"status\":500,\"title\":\"Internal Server Error\"}\n",
+			expectedResponse:        "{\"code\":\"internal_error\",\"detail\":\"internal server error\",\"instance\":\"/v1/rentals/500\",\"status\":500,\"title\":\"Internal Server Error\",\"type\":\"about:blank\"}\n",
 			expectedStatusCode:      http.StatusInternalServerError,
 		},
 	}
@@ -74,20 +78,58 @@ func TestRentals_GetRental(t *testing.T) {
 			// Given
 			ctrl := gomock.NewController(t)
 			service := services.NewMockRentals(ctrl)
-			service.EXPECT().GetRental(gomock.Any(), tc.id).Return(tc.expectedServiceResponse, tc.expectedServiceError)
-			controller := NewRentalsController(service)
-			e := echo.New()
-			rec := httptest.NewRecorder()
-			req := httptest.NewRequest(http.MethodGet, "/v1/rentals/:rental_id", nil)
-			ctx := e.NewContext(req, rec)
-			ctx.SetParamNames("rental_id")
-			ctx.SetParamValues(fmt.Sprintf("%d", tc.id))
+			service.EXPECT().GetRental(gomock.Any(), tc.id, "").Return(tc.expectedServiceResponse, tc.expectedServiceError)
+			server := &Server{RentalsController: NewRentalsController(service)}
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/rentals/%d", tc.id), nil)
 
 			// When
-			err := controller.GetRental(ctx)
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestRentals_GetRental_InCurrency(t *testing.T) {
+	testCases := []struct {
+		name               string
+		query              string
+		expectedCurrency   string
+		expectedResponse   string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Currency in lower case",
+			query:              "currency=eur",
+			expectedCurrency:   "EUR",
+			expectedResponse:   "{\"description\":\"\",\"id\":1,\"length\":0,\"location\":{\"city\":\"\",\"country\":\"\",\"lat\":0,\"lng\":0,\"state\":\"\",\"zip\":\"\"},\"make\":\"\",\"model\":\"\",\"name\":\"\",\"price\":{\"currency\":\"EUR\",\"day\":15210},\"primary_image_url\":\"\",\"sleeps\":0,\"type\":\"\",\"user\":{\"first_name\":\"\",\"id\":0,\"last_name\":\"\"},\"year\":0}\n",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Currency is not a currency code",
+			query:              "currency=euro",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"currency\",\"message\":\"must match ^[A-Za-z]{3}$\"}],\"instance\":\"/v1/rentals/1\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockRentals(ctrl)
+			if tc.expectedCurrency != "" {
+				service.EXPECT().GetRental(gomock.Any(), 1, tc.expectedCurrency).Return(&models.Rental{Id: 1, Price: models.Price{PerDay: 15210, Currency: "EUR"}}, nil)
+			}
+			server := &Server{RentalsController: NewRentalsController(service)}
+			req := httptest.NewRequest(http.MethodGet, "/v1/rentals/1?"+tc.query, nil)
+
+			// When
+			rec := serve(t, server, req)
 
 			// Then
-			assert.NoError(t, err)
 			assert.Equal(t, tc.expectedStatusCode, rec.Code)
 			assert.Equal(t, tc.expectedResponse, rec.Body.String())
 		})
@@ -134,7 +176,7 @@ func TestRentals_GetRentals(t *testing.T) {
 				Offset:   0,
 				Ids:      []int{1, 2, 3},
 				Near:     []float64{19.99, -19.99},
-				Sort:     "price",
+				Sort:     []models.SortField{{Field: "price"}},
 			},
 			expectedServiceResponse: []models.Rental{
 				{
@@ -148,7 +190,7 @@ func TestRentals_GetRentals(t *testing.T) {
 					Length:          10,
 					Sleeps:          2,
 					PrimaryImageUrl: "Test Primary Image URL",
-					Price:           models.Price{PerDay: 1},
+					Price:           models.Price{PerDay: 1, Currency: "USD"},
 					Location: models.Location{
 						City:    "Test City",
 						State:   "Test State",
@@ -161,7 +203,7 @@ func TestRentals_GetRentals(t *testing.T) {
 				},
 			},
 			expectedServiceError: nil,
-			expectedResponse:     "[{\"description\":\"Test Description\",\"id\":1,\"length\":10,\"location\":{\"city\":\"Test City\",\"country\":\"Test Country\",\"lat\":19.99,\"lng\":-19.99,\"state\":\"Test State\",\"zip\":\"Test Zip\"},\"make\":\"Test Make\",\"model\":\"Test Model\",\"name\":\"Test Rental\",\"price\":{\"day\":1},\"primary_image_url\":\"Test Primary Image URL\",\"sleeps\":2,\"type\":\"Test Type\",\"user\":{\"first_name\":\"Test First Name\",\"id\":0,\"last_name\":\"Test Last Name\"},\"year\":2020}]\n",
+			expectedResponse:     "[{\"description\":\"Test Description\",\"id\":1,\"length\":10,\"location\":{\"city\":\"Test City\",\"country\":\"Test Country\",\"lat\":19.99,\"lng\":-19.99,\"state\":\"Test State\",\"zip\":\"Test Zip\"},\"make\":\"Test Make\",\"model\":\"Test Model\",\"name\":\"Test Rental\",\"price\":{\"currency\":\"USD\",\"day\":1},\"primary_image_url\":\"Test Primary Image URL\",\"sleeps\":2,\"type\":\"Test Type\",\"user\":{\"first_name\":\"Test First Name\",\"id\":0,\"last_name\":\"Test Last Name\"},\"year\":2020}]\n",
 			expectedStatusCode:   http.StatusOK,
 		},
 		{
@@ -177,7 +219,7 @@ func TestRentals_GetRentals(t *testing.T) {
 			params:                  models.GetRentalsParams{},
 			expectedServiceResponse: nil,
 			expectedServiceError:    fmt.Errorf("test error"),
-			expectedResponse:        "{\"details\":\"internal server error\",\"status\":500,\"title\":\"Internal Server Error\"}\n",
+			expectedResponse:        "{\"code\":\"internal_error\",\"detail\":\"internal server error\",\"instance\":\"/v1/rentals\",\"status\":500,\"title\":\"Internal Server Error\",\"type\":\"about:blank\"}\n",
 			expectedStatusCode:      http.StatusInternalServerError,
 		},
 	}
@@ -187,12 +229,13 @@ func TestRentals_GetRentals(t *testing.T) {
 			// Given
 			ctrl := gomock.NewController(t)
 			service := services.NewMockRentals(ctrl)
-			service.EXPECT().GetRentals(gomock.Any(), tc.params).Return(tc.expectedServiceResponse, tc.expectedServiceError)
-			controller := NewRentalsController(service)
-			e := echo.New()
-			rec := httptest.NewRecorder()
+			var page *models.RentalsPage
+			if tc.expectedServiceError == nil {
+				page = &models.RentalsPage{Rentals: tc.expectedServiceResponse, Total: len(tc.expectedServiceResponse), Limit: models.DefaultLimit}
+			}
+			service.EXPECT().GetRentals(gomock.Any(), tc.params).Return(page, tc.expectedServiceError)
+			server := &Server{RentalsController: NewRentalsController(service)}
 			req := httptest.NewRequest(http.MethodGet, "/v1/rentals", nil)
-			ctx := e.NewContext(req, rec)
 			// set query params
 			q := req.URL.Query()
 			if tc.priceMin != nil {
@@ -219,12 +262,841 @@ func TestRentals_GetRentals(t *testing.T) {
 			req.URL.RawQuery = q.Encode()
 
 			// When
-			err := controller.GetRentals(ctx)
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestRentals_GetRentals_InvalidQueryParams(t *testing.T) {
+	testCases := []struct {
+		name               string
+		query              string
+		expectedResponse   string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Price is not a number",
+			query:              "price_min=abc",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"price_min\",\"message\":\"must be an integer\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Price max lower than price min",
+			query:              "price_min=200&price_max=100",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"price_max\",\"message\":\"must be greater than or equal to price_min\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Near with a single coordinate",
+			query:              "near=33.6",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"near\",\"message\":\"must have exactly 2 items\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Near latitude out of range",
+			query:              "near=95,-117.93",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"near\",\"message\":\"latitude must be between -90 and 90\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown sort field",
+			query:              "sort=price,-user_id",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"sort\",\"message\":\"must be one of 'price', '-price', 'year', '-year', 'length', '-length', 'sleeps', '-sleeps', 'name', '-name', 'created', '-created', 'distance', '-distance', 'relevance', '-relevance'\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Sort by distance without near",
+			query:              "sort=-distance",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"sort\",\"message\":\"sorting by distance requires near\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Radius without near",
+			query:              "radius=10",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"radius\",\"message\":\"requires near\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown distance unit",
+			query:              "near=33.64,-117.93&unit=ft",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"unit\",\"message\":\"must be one of 'mi', 'km'\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Bounding box with three values",
+			query:              "bbox=1,2,3",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"bbox\",\"message\":\"must have exactly 4 items\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Bounding box with inverted latitudes",
+			query:              "bbox=-120,35,-117,32",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"bbox\",\"message\":\"minLat must not be greater than maxLat\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Malformed cursor",
+			query:              "cursor=not-a-cursor",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"cursor\",\"message\":\"must be a cursor returned by a previous page\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Cursor with offset",
+			query:              "offset=20&cursor=" + models.Cursor{Id: 6}.Encode(),
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"cursor\",\"message\":\"can not be combined with offset\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Start date is not a date",
+			query:              "start_date=2030-13-01&end_date=2030-07-08",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"start_date\",\"message\":\"must be a date formatted as YYYY-MM-DD\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Start date without end date",
+			query:              "start_date=2030-07-01",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"end_date\",\"message\":\"is required with start_date\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "End date not after start date",
+			query:              "start_date=2030-07-08&end_date=2030-07-08",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"end_date\",\"message\":\"must be after start_date\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Currency is not a currency code",
+			query:              "currency=euro",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"currency\",\"message\":\"must match ^[A-Za-z]{3}$\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Type with an empty value",
+			query:              "type=camper-van,",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"type\",\"message\":\"must not be empty\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Make is blank",
+			query:              "make=%20",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"make\",\"message\":\"must not be empty\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Year max lower than year min",
+			query:              "year_min=2015&year_max=2010",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"year_max\",\"message\":\"must be greater than or equal to year_min\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Sleeps is not a number",
+			query:              "sleeps_min=many",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"sleeps_min\",\"message\":\"must be an integer\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Length max lower than length min",
+			query:              "length_min=20&length_max=15.5",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"length_max\",\"message\":\"must be greater than or equal to length_min\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Query is blank",
+			query:              "q=%20%20",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"q\",\"message\":\"must not be empty\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Query is too long",
+			query:              "q=" + strings.Repeat("van", 67),
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"q\",\"message\":\"must not be longer than 200 characters\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Sort by relevance without query",
+			query:              "sort=relevance",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"sort\",\"message\":\"sorting by relevance requires q\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Multiple invalid parameters",
+			query:              "ids=1,x&limit=0&offset=-1",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"limit\",\"message\":\"must be between 1 and 100\"},{\"field\":\"offset\",\"message\":\"must not be negative\"},{\"field\":\"ids\",\"message\":\"must be a comma separated list of integers\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockRentals(ctrl)
+			server := &Server{RentalsController: NewRentalsController(service)}
+			req := httptest.NewRequest(http.MethodGet, "/v1/rentals?"+tc.query, nil)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestRentals_GetRentals_NearWithRadius(t *testing.T) {
+	// Given
+	distance := 12.3456
+	ctrl := gomock.NewController(t)
+	service := services.NewMockRentals(ctrl)
+	service.EXPECT().GetRentals(gomock.Any(), models.GetRentalsParams{
+		Near:   []float64{33.64, -117.93},
+		Radius: 25,
+		Unit:   models.DistanceUnitKilometers,
+		Sort:   []models.SortField{{Field: models.SortFieldDistance}},
+	}).Return(&models.RentalsPage{Rentals: []models.Rental{{Id: 1, Price: models.Price{Currency: "USD"}, Distance: &distance}}, Total: 1, Limit: models.DefaultLimit}, nil)
+	server := &Server{RentalsController: NewRentalsController(service)}
+	req := httptest.NewRequest(http.MethodGet, "/v1/rentals?near=33.64,-117.93&radius=25&unit=km&sort=distance", nil)
+
+	// When
+	rec := serve(t, server, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"distance\":12.35")
+}
+
+func TestRentals_GetRentals_AvailableBetweenDates(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	service := services.NewMockRentals(ctrl)
+	service.EXPECT().GetRentals(gomock.Any(), models.GetRentalsParams{
+		Available: &models.DateRange{
+			Start: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2030, 7, 8, 0, 0, 0, 0, time.UTC),
+		},
+	}).Return(&models.RentalsPage{Limit: models.DefaultLimit}, nil)
+	server := &Server{RentalsController: NewRentalsController(service)}
+	req := httptest.NewRequest(http.MethodGet, "/v1/rentals?start_date=2030-07-01&end_date=2030-07-08", nil)
+
+	// When
+	rec := serve(t, server, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRentals_GetRentals_ByAttributes(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	service := services.NewMockRentals(ctrl)
+	service.EXPECT().GetRentals(gomock.Any(), models.GetRentalsParams{
+		Types:     []string{"camper-van", "trailer"},
+		Make:      "Volkswagen",
+		Model:     "Westfalia",
+		YearMin:   1980,
+		YearMax:   1990,
+		SleepsMin: 4,
+		LengthMin: 14.5,
+		LengthMax: 16,
+	}).Return(&models.RentalsPage{Limit: models.DefaultLimit}, nil)
+	server := &Server{RentalsController: NewRentalsController(service)}
+	req := httptest.NewRequest(http.MethodGet, "/v1/rentals?type=camper-van,trailer&make=Volkswagen&model=Westfalia&year_min=1980&year_max=1990&sleeps_min=4&length_min=14.5&length_max=16", nil)
+
+	// When
+	rec := serve(t, server, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRentals_GetRentals_ByQuery(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	service := services.NewMockRentals(ctrl)
+	service.EXPECT().GetRentals(gomock.Any(), models.GetRentalsParams{
+		Query: "vw westfalia",
+		Sort:  []models.SortField{{Field: models.SortFieldRelevance}},
+	}).Return(&models.RentalsPage{Limit: models.DefaultLimit}, nil)
+	server := &Server{RentalsController: NewRentalsController(service)}
+	req := httptest.NewRequest(http.MethodGet, "/v1/rentals?q=+vw+westfalia+&sort=relevance", nil)
+
+	// When
+	rec := serve(t, server, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRentals_GetRentals_InCurrency(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	service := services.NewMockRentals(ctrl)
+	service.EXPECT().GetRentals(gomock.Any(), models.GetRentalsParams{
+		PriceMax: 10000,
+		Currency: "EUR",
+	}).Return(&models.RentalsPage{Rentals: []models.Rental{{Id: 11, Price: models.Price{PerDay: 8900, Currency: "EUR"}}}, Total: 1, Limit: models.DefaultLimit}, nil)
+	server := &Server{RentalsController: NewRentalsController(service)}
+	req := httptest.NewRequest(http.MethodGet, "/v1/rentals?price_max=10000&currency=eur", nil)
+
+	// When
+	rec := serve(t, server, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"price\":{\"currency\":\"EUR\",\"day\":8900}")
+}
+
+func TestRentals_GetRentalFacets(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	service := services.NewMockRentals(ctrl)
+	service.EXPECT().GetFacets(gomock.Any(), models.GetRentalsParams{Make: "toyota"}).Return(&models.RentalFacets{
+		Total:  3,
+		Types:  []models.FacetCount{{Value: "camper-van", Count: 3}},
+		Makes:  []models.FacetCount{{Value: "Toyota", Count: 3}},
+		Sleeps: []models.FacetCount{{Value: "2", Count: 2}, {Value: "4", Count: 1}},
+		Price: &models.PriceStats{
+			Min: 5000, Max: 5900, P25: 5000, Median: 5500, P75: 5900,
+			Histogram: []models.PriceBucket{{Min: 5000, Max: 5500, Count: 1}, {Min: 5500, Max: 6000, Count: 2}},
+		},
+	}, nil)
+	server := &Server{RentalsController: NewRentalsController(service)}
+	req := httptest.NewRequest(http.MethodGet, "/v1/rentals/facets?make=toyota", nil)
+
+	// When
+	rec := serve(t, server, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"total": 3,
+		"types": [{"value": "camper-van", "count": 3}],
+		"makes": [{"value": "Toyota", "count": 3}],
+		"states": [],
+		"countries": [],
+		"sleeps": [{"value": "2", "count": 2}, {"value": "4", "count": 1}],
+		"price": {
+			"min": 5000, "max": 5900, "p25": 5000, "median": 5500, "p75": 5900,
+			"histogram": [{"min": 5000, "max": 5500, "count": 1}, {"min": 5500, "max": 6000, "count": 2}]
+		}
+	}`, rec.Body.String())
+}
+
+func TestRentals_GetRentalFacets_InvalidQueryParams(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	server := &Server{RentalsController: NewRentalsController(services.NewMockRentals(ctrl))}
+	req := httptest.NewRequest(http.MethodGet, "/v1/rentals/facets?year_min=1990&year_max=1980", nil)
+
+	// When
+	rec := serve(t, server, req)
+
+	// Then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "must be greater than or equal to year_min")
+}
+
+func TestRentals_GetRentals_BBoxCrossingAntimeridian(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	service := services.NewMockRentals(ctrl)
+	service.EXPECT().GetRentals(gomock.Any(), models.GetRentalsParams{
+		BBox: &models.BoundingBox{MinLng: 170, MinLat: 15, MaxLng: -150, MaxLat: 25},
+	}).Return(&models.RentalsPage{Limit: models.DefaultLimit}, nil)
+	server := &Server{RentalsController: NewRentalsController(service)}
+	req := httptest.NewRequest(http.MethodGet, "/v1/rentals?bbox=170,15,-150,25", nil)
+
+	// When
+	rec := serve(t, server, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "[]\n", rec.Body.String())
+}
+
+func TestRentals_SearchRentals(t *testing.T) {
+	testCases := []struct {
+		name               string
+		query              string
+		body               string
+		params             *models.GetRentalsParams
+		serviceError       error
+		expectedResponse   string
+		expectedStatusCode int
+	}{
+		{
+			name:  "Search within a polygon",
+			query: "price_max=10000",
+			body:  `{"geometry":{"type":"Polygon","coordinates":[[[170,15],[-150,15],[-150,25],[170,25],[170,15]]]}}`,
+			params: &models.GetRentalsParams{
+				PriceMax: 10000,
+				Polygons: []models.Polygon{{{{170, 15}, {-150, 15}, {-150, 25}, {170, 25}, {170, 15}}}},
+			},
+			expectedResponse:   "[]\n",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Search within a multi polygon",
+			body: `{"geometry":{"type":"MultiPolygon","coordinates":[[[[170,15],[180,15],[180,25],[170,15]]],[[[-180,15],[-150,15],[-150,25],[-180,15]]]]}}`,
+			params: &models.GetRentalsParams{
+				Polygons: []models.Polygon{
+					{{{170, 15}, {180, 15}, {180, 25}, {170, 15}}},
+					{{{-180, 15}, {-150, 15}, {-150, 25}, {-180, 15}}},
+				},
+			},
+			expectedResponse:   "[]\n",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Search without PostGIS",
+			body: `{"geometry":{"type":"Polygon","coordinates":[[[170,15],[-150,15],[-150,25],[170,25],[170,15]]]}}`,
+			params: &models.GetRentalsParams{
+				Polygons: []models.Polygon{{{{170, 15}, {-150, 15}, {-150, 25}, {170, 25}, {170, 15}}}},
+			},
+			serviceError:       models.NewUnavailableError("polygon search requires the PostGIS geog column", nil),
+			expectedResponse:   "{\"code\":\"unavailable\",\"detail\":\"service unavailable\",\"instance\":\"/v1/rentals/search\",\"status\":503,\"title\":\"Service Unavailable\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:               "Unsupported geometry type",
+			body:               `{"geometry":{"type":"Point","coordinates":[170,15]}}`,
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"geometry.type\",\"message\":\"must be one of 'Polygon', 'MultiPolygon'\"}],\"instance\":\"/v1/rentals/search\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Ring is not closed",
+			body:               `{"geometry":{"type":"Polygon","coordinates":[[[170,15],[-150,15],[-150,25],[170,25]]]}}`,
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid geometry\",\"errors\":[{\"field\":\"geometry.coordinates\",\"message\":\"rings must be closed, with the same first and last position\"}],\"instance\":\"/v1/rentals/search\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Coordinates do not match the type",
+			body:               `{"geometry":{"type":"MultiPolygon","coordinates":[[170,15],[-150,15]]}}`,
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid geometry\",\"errors\":[{\"field\":\"geometry.coordinates\",\"message\":\"must be the coordinates of a GeoJSON MultiPolygon\"}],\"instance\":\"/v1/rentals/search\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Body is not JSON",
+			body:               `polygon`,
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"body\",\"message\":\"must be a JSON object\"}],\"instance\":\"/v1/rentals/search\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockRentals(ctrl)
+			if tc.serviceError != nil {
+				service.EXPECT().GetRentals(gomock.Any(), *tc.params).Return(nil, tc.serviceError)
+			} else if tc.params != nil {
+				service.EXPECT().GetRentals(gomock.Any(), *tc.params).Return(&models.RentalsPage{Limit: models.DefaultLimit}, nil)
+			}
+			server := &Server{RentalsController: NewRentalsController(service)}
+			req := httptest.NewRequest(http.MethodPost, "/v1/rentals/search?"+tc.query, strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestRentals_GetRentals_PaginationHeaders(t *testing.T) {
+	cursor := models.Cursor{Keys: []string{"16900"}, Id: 1, Filter: "filter"}
+	nextCursor := models.Cursor{Keys: []string{"18000"}, Id: 3, Filter: "filter"}
+
+	testCases := []struct {
+		name               string
+		query              string
+		page               models.RentalsPage
+		expectedTotal      string
+		expectedNextCursor string
+		expectedLink       string
+	}{
+		{
+			name:          "First page",
+			query:         "limit=10&price_min=100",
+			page:          models.RentalsPage{Total: 25, Limit: 10, Offset: 0},
+			expectedTotal: "25",
+			expectedLink:  `</v1/rentals?limit=10&offset=0&price_min=100>; rel="first", </v1/rentals?limit=10&offset=10&price_min=100>; rel="next", </v1/rentals?limit=10&offset=20&price_min=100>; rel="last"`,
+		},
+		{
+			name:               "First page with a next cursor",
+			query:              "limit=10&sort=price",
+			page:               models.RentalsPage{Total: 25, Limit: 10, Offset: 0, NextCursor: &nextCursor},
+			expectedTotal:      "25",
+			expectedNextCursor: nextCursor.Encode(),
+			expectedLink:       `</v1/rentals?limit=10&offset=0&sort=price>; rel="first", </v1/rentals?limit=10&offset=10&sort=price>; rel="next", </v1/rentals?limit=10&offset=20&sort=price>; rel="last"`,
+		},
+		{
+			name:               "Page after a cursor",
+			query:              "limit=10&sort=price&cursor=" + cursor.Encode(),
+			page:               models.RentalsPage{Total: 25, Limit: 10, NextCursor: &nextCursor},
+			expectedTotal:      "25",
+			expectedNextCursor: nextCursor.Encode(),
+			expectedLink:       `</v1/rentals?limit=10&sort=price>; rel="first", </v1/rentals?cursor=` + nextCursor.Encode() + `&limit=10&sort=price>; rel="next"`,
+		},
+		{
+			name:          "Last page after a cursor",
+			query:         "limit=10&sort=price&cursor=" + cursor.Encode(),
+			page:          models.RentalsPage{Total: 25, Limit: 10},
+			expectedTotal: "25",
+			expectedLink:  `</v1/rentals?limit=10&sort=price>; rel="first"`,
+		},
+		{
+			name:          "Middle page not aligned to the limit",
+			query:         "limit=10&offset=5",
+			page:          models.RentalsPage{Total: 25, Limit: 10, Offset: 5},
+			expectedTotal: "25",
+			expectedLink:  `</v1/rentals?limit=10&offset=0>; rel="first", </v1/rentals?limit=10&offset=0>; rel="prev", </v1/rentals?limit=10&offset=15>; rel="next", </v1/rentals?limit=10&offset=20>; rel="last"`,
+		},
+		{
+			name:          "Last page",
+			query:         "limit=10&offset=20",
+			page:          models.RentalsPage{Total: 25, Limit: 10, Offset: 20},
+			expectedTotal: "25",
+			expectedLink:  `</v1/rentals?limit=10&offset=0>; rel="first", </v1/rentals?limit=10&offset=10>; rel="prev", </v1/rentals?limit=10&offset=20>; rel="last"`,
+		},
+		{
+			name:          "No rentals",
+			query:         "",
+			page:          models.RentalsPage{Total: 0, Limit: models.DefaultLimit, Offset: 0},
+			expectedTotal: "0",
+			expectedLink:  `</v1/rentals?limit=20&offset=0>; rel="first", </v1/rentals?limit=20&offset=0>; rel="last"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			page := tc.page
+			ctrl := gomock.NewController(t)
+			service := services.NewMockRentals(ctrl)
+			service.EXPECT().GetRentals(gomock.Any(), gomock.Any()).Return(&page, nil)
+			server := &Server{RentalsController: NewRentalsController(service)}
+			req := httptest.NewRequest(http.MethodGet, "/v1/rentals?"+tc.query, nil)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.expectedTotal, rec.Header().Get("X-Total-Count"))
+			assert.Equal(t, tc.expectedNextCursor, rec.Header().Get("X-Next-Cursor"))
+			assert.Equal(t, tc.expectedLink, rec.Header().Get("Link"))
+		})
+	}
+}
+
+const rentalInputJSON = `{"user_id":3,"name":"Test Rental","description":"Test Description","type":"camper-van","make":"Volkswagen","model":"Westfalia","year":1984,"length":16,"sleeps":4,"primary_image_url":"https://example.com/image.jpg","price":{"day":18000},"location":{"city":"San Diego","state":"CA","zip":"92037","country":"US","lat":32.83,"lng":-117.28}}`
+
+func inputRental() models.Rental {
+	return models.Rental{
+		Name:            "Test Rental",
+		Description:     "Test Description",
+		Type:            "camper-van",
+		Make:            "Volkswagen",
+		Model:           "Westfalia",
+		Year:            1984,
+		Length:          16,
+		Sleeps:          4,
+		PrimaryImageUrl: "https://example.com/image.jpg",
+		Price:           models.Price{PerDay: 18000, Currency: "USD"},
+		Location:        models.Location{City: "San Diego", State: "CA", Zip: "92037", Country: "US", Lat: 32.83, Lng: -117.28},
+		User:            models.User{Id: 3},
+	}
+}
+
+func storedRental(id int) *models.Rental {
+	rental := inputRental()
+	rental.Id = id
+	rental.User = models.User{Id: 3, FirstName: "Barry", LastName: "Martin"}
+	rental.Created = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rental.Updated = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &rental
+}
+
+func TestRentals_CreateRental(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		body                    string
+		expectedServiceResponse *models.Rental
+		expectedServiceError    error
+		expectedResponse        string
+		expectedLocation        string
+		expectedStatusCode      int
+	}{
+		{
+			name:                    "Create a rental",
+			body:                    rentalInputJSON,
+			expectedServiceResponse: storedRental(31),
+			expectedResponse:        "{\"created\":\"2024-01-02T03:04:05Z\",\"description\":\"Test Description\",\"id\":31,\"length\":16,\"location\":{\"city\":\"San Diego\",\"country\":\"US\",\"lat\":32.83,\"lng\":-117.28,\"state\":\"CA\",\"zip\":\"92037\"},\"make\":\"Volkswagen\",\"model\":\"Westfalia\",\"name\":\"Test Rental\",\"price\":{\"currency\":\"USD\",\"day\":18000},\"primary_image_url\":\"https://example.com/image.jpg\",\"sleeps\":4,\"type\":\"camper-van\",\"updated\":\"2024-01-02T03:04:05Z\",\"user\":{\"first_name\":\"Barry\",\"id\":3,\"last_name\":\"Martin\"},\"year\":1984}\n",
+			expectedLocation:        "/v1/rentals/31",
+			expectedStatusCode:      http.StatusCreated,
+		},
+		{
+			name:                 "Create an invalid rental",
+			body:                 rentalInputJSON,
+			expectedServiceError: models.NewBadRequestError("invalid rental", models.FieldError{Field: "user_id", Msg: "user with id 3 does not exist"}),
+			expectedResponse:     "{\"code\":\"invalid_request\",\"detail\":\"invalid rental\",\"errors\":[{\"field\":\"user_id\",\"message\":\"user with id 3 does not exist\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusBadRequest,
+		},
+		{
+			name:               "Body is not JSON",
+			body:               `rental`,
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"body\",\"message\":\"must be a JSON object\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockRentals(ctrl)
+			if tc.expectedServiceResponse != nil || tc.expectedServiceError != nil {
+				service.EXPECT().CreateRental(gomock.Any(), inputRental()).Return(tc.expectedServiceResponse, tc.expectedServiceError)
+			}
+			server := &Server{RentalsController: NewRentalsController(service)}
+			req := httptest.NewRequest(http.MethodPost, "/v1/rentals", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+			assert.Equal(t, tc.expectedLocation, rec.Header().Get(echo.HeaderLocation))
+		})
+	}
+}
+
+func TestRentals_UpdateRental(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		id                      string
+		expectedServiceResponse *models.Rental
+		expectedServiceError    error
+		expectedResponse        string
+		expectedStatusCode      int
+	}{
+		{
+			name:                    "Update an existing rental",
+			id:                      "3",
+			expectedServiceResponse: storedRental(3),
+			expectedResponse:        "{\"created\":\"2024-01-02T03:04:05Z\",\"description\":\"Test Description\",\"id\":3,\"length\":16,\"location\":{\"city\":\"San Diego\",\"country\":\"US\",\"lat\":32.83,\"lng\":-117.28,\"state\":\"CA\",\"zip\":\"92037\"},\"make\":\"Volkswagen\",\"model\":\"Westfalia\",\"name\":\"Test Rental\",\"price\":{\"currency\":\"USD\",\"day\":18000},\"primary_image_url\":\"https://example.com/image.jpg\",\"sleeps\":4,\"type\":\"camper-van\",\"updated\":\"2024-01-02T03:04:05Z\",\"user\":{\"first_name\":\"Barry\",\"id\":3,\"last_name\":\"Martin\"},\"year\":1984}\n",
+			expectedStatusCode:      http.StatusOK,
+		},
+		{
+			name:                 "Update a non-existing rental",
+			id:                   "404",
+			expectedServiceError: models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 404 not found"),
+			expectedResponse:     "{\"code\":\"rental_not_found\",\"detail\":\"rental with id 404 not found\",\"instance\":\"/v1/rentals/404\",\"status\":404,\"title\":\"Not Found\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusNotFound,
+		},
+		{
+			name:               "Id is not a number",
+			id:                 "abc",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"rental_id\",\"message\":\"must be an integer\"}],\"instance\":\"/v1/rentals/abc\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockRentals(ctrl)
+			if tc.expectedServiceResponse != nil || tc.expectedServiceError != nil {
+				rental := inputRental()
+				rental.Id, _ = strconv.Atoi(tc.id)
+				service.EXPECT().UpdateRental(gomock.Any(), rental).Return(tc.expectedServiceResponse, tc.expectedServiceError)
+			}
+			server := &Server{RentalsController: NewRentalsController(service)}
+			req := httptest.NewRequest(http.MethodPut, "/v1/rentals/"+tc.id, strings.NewReader(rentalInputJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			// When
+			rec := serve(t, server, req)
 
 			// Then
-			assert.NoError(t, err)
 			assert.Equal(t, tc.expectedStatusCode, rec.Code)
 			assert.Equal(t, tc.expectedResponse, rec.Body.String())
 		})
 	}
 }
+
+func TestRentals_PatchRental(t *testing.T) {
+	// Given
+	price := int64(20000)
+	lat := 32.9
+	patched := storedRental(3)
+	patched.Price.PerDay = price
+	patched.Location.Lat = lat
+	ctrl := gomock.NewController(t)
+	service := services.NewMockRentals(ctrl)
+	service.EXPECT().PatchRental(gomock.Any(), 3, models.RentalPatch{PricePerDay: &price, Lat: &lat}).Return(patched, nil)
+	server := &Server{RentalsController: NewRentalsController(service)}
+	req := httptest.NewRequest(http.MethodPatch, "/v1/rentals/3", strings.NewReader(`{"price":{"day":20000},"location":{"lat":32.9}}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	// When
+	rec := serve(t, server, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"created\":\"2024-01-02T03:04:05Z\",\"description\":\"Test Description\",\"id\":3,\"length\":16,\"location\":{\"city\":\"San Diego\",\"country\":\"US\",\"lat\":32.9,\"lng\":-117.28,\"state\":\"CA\",\"zip\":\"92037\"},\"make\":\"Volkswagen\",\"model\":\"Westfalia\",\"name\":\"Test Rental\",\"price\":{\"currency\":\"USD\",\"day\":20000},\"primary_image_url\":\"https://example.com/image.jpg\",\"sleeps\":4,\"type\":\"camper-van\",\"updated\":\"2024-01-02T03:04:05Z\",\"user\":{\"first_name\":\"Barry\",\"id\":3,\"last_name\":\"Martin\"},\"year\":1984}\n", rec.Body.String())
+}
+
+func TestRentals_DeleteRental(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		id                   int
+		expectedServiceError error
+		expectedResponse     string
+		expectedStatusCode   int
+	}{
+		{
+			name:                 "Delete an existing rental",
+			id:                   1,
+			expectedServiceError: nil,
+			expectedResponse:     "",
+			expectedStatusCode:   http.StatusNoContent,
+		},
+		{
+			name:                 "Delete a non-existing rental",
+			id:                   404,
+			expectedServiceError: models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 404 not found"),
+			expectedResponse:     "{\"code\":\"rental_not_found\",\"detail\":\"rental with id 404 not found\",\"instance\":\"/v1/rentals/404\",\"status\":404,\"title\":\"Not Found\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockRentals(ctrl)
+			service.EXPECT().DeleteRental(gomock.Any(), tc.id).Return(tc.expectedServiceError)
+			server := &Server{RentalsController: NewRentalsController(service)}
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/v1/rentals/%d", tc.id), nil)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestRentals_GetAvailability(t *testing.T) {
+	july := models.DateRange{Start: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC)}
+
+	testCases := []struct {
+		name                    string
+		query                   string
+		expectedDates           *models.DateRange
+		expectedServiceResponse *models.Availability
+		expectedServiceError    error
+		expectedResponse        string
+		expectedStatusCode      int
+	}{
+		{
+			name:          "Get the availability of an existing rental",
+			query:         "from=2030-07-01&to=2030-08-01",
+			expectedDates: &july,
+			expectedServiceResponse: &models.Availability{
+				RentalId:  1,
+				DateRange: july,
+				Unavailable: []models.UnavailablePeriod{
+					{
+						DateRange: models.DateRange{Start: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 7, 8, 0, 0, 0, 0, time.UTC)},
+						Reason:    models.UnavailableReasonBooked,
+					},
+				},
+			},
+			expectedResponse:   "{\"available\":false,\"from\":\"2030-07-01\",\"rental_id\":1,\"to\":\"2030-08-01\",\"unavailable\":[{\"end_date\":\"2030-07-08\",\"reason\":\"booked\",\"start_date\":\"2030-07-01\"}]}\n",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:                    "Get the availability of an available rental",
+			query:                   "from=2030-07-01&to=2030-08-01",
+			expectedDates:           &july,
+			expectedServiceResponse: &models.Availability{RentalId: 1, DateRange: july},
+			expectedResponse:        "{\"available\":true,\"from\":\"2030-07-01\",\"rental_id\":1,\"to\":\"2030-08-01\",\"unavailable\":[]}\n",
+			expectedStatusCode:      http.StatusOK,
+		},
+		{
+			name:                 "Get the availability of a non-existing rental",
+			query:                "from=2030-07-01&to=2030-08-01",
+			expectedDates:        &july,
+			expectedServiceError: models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 1 not found"),
+			expectedResponse:     "{\"code\":\"rental_not_found\",\"detail\":\"rental with id 1 not found\",\"instance\":\"/v1/rentals/1/availability\",\"status\":404,\"title\":\"Not Found\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusNotFound,
+		},
+		{
+			name:               "To is before from",
+			query:              "from=2030-07-01&to=2030-06-01",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"to\",\"message\":\"must be after from\"}],\"instance\":\"/v1/rentals/1/availability\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Range is too long",
+			query:              "from=2030-07-01&to=2031-07-03",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid query parameters\",\"errors\":[{\"field\":\"to\",\"message\":\"must not be more than 366 days after from\"}],\"instance\":\"/v1/rentals/1/availability\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "From is not a date",
+			query:              "from=tomorrow",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"from\",\"message\":\"must be a date formatted as YYYY-MM-DD\"}],\"instance\":\"/v1/rentals/1/availability\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockRentals(ctrl)
+			if tc.expectedDates != nil {
+				service.EXPECT().GetAvailability(gomock.Any(), 1, *tc.expectedDates).Return(tc.expectedServiceResponse, tc.expectedServiceError)
+			}
+			server := &Server{RentalsController: NewRentalsController(service)}
+			req := httptest.NewRequest(http.MethodGet, "/v1/rentals/1/availability?"+tc.query, nil)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestRentals_ConsumeAvailabilityParams_Defaults(t *testing.T) {
+	// Given
+	now := time.Date(2030, 7, 1, 15, 30, 0, 0, time.UTC)
+
+	// When
+	dates, err := consumeAvailabilityParams(api.GetRentalAvailabilityParams{}, now)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, models.DateRange{Start: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 7, 31, 0, 0, 0, 0, time.UTC)}, dates)
+}
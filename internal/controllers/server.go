@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	"github.com/toshko07/outdoorsy-challenge/api"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+// Server implements the operations generated from api-definition.yaml, each one by the controller of its resource.
+type Server struct {
+	*RentalsController
+	*UsersController
+	*BookingsController
+	*PricingController
+}
+
+var _ api.StrictServerInterface = (*Server)(nil)
+
+func NewServer(rentals *RentalsController, users *UsersController, bookings *BookingsController, pricing *PricingController) *Server {
+	return &Server{
+		RentalsController:  rentals,
+		UsersController:    users,
+		BookingsController: bookings,
+		PricingController:  pricing,
+	}
+}
+
+// RegisterHandlers routes the operations of the API to the server, validating the requests against its spec
+// and responding to the returned errors with HTTPErrorHandler.
+func RegisterHandlers(e *echo.Echo, server *Server, options ValidatorOptions) error {
+	validator, err := NewValidator(options)
+	if err != nil {
+		return err
+	}
+
+	// A group would route the unknown methods of its paths to not found, instead of method not allowed.
+	e.Use(validator)
+	e.HTTPErrorHandler = HTTPErrorHandler
+	api.RegisterHandlers(e, api.NewStrictHandler(server, []api.StrictMiddlewareFunc{withRequestURL}))
+
+	return nil
+}
+
+type requestURLKey struct{}
+
+// withRequestURL passes the URL of the request on to the operations, which link to other pages of it.
+func withRequestURL(f api.StrictHandlerFunc, _ string) api.StrictHandlerFunc {
+	return func(e echo.Context, request interface{}) (interface{}, error) {
+		ctx := context.WithValue(e.Request().Context(), requestURLKey{}, e.Request().URL)
+		e.SetRequest(e.Request().WithContext(ctx))
+		return f(e, request)
+	}
+}
+
+// requestURL returns the URL of the request the operation serves, or an empty URL outside of one.
+func requestURL(ctx context.Context) *url.URL {
+	if requestURL, ok := ctx.Value(requestURLKey{}).(*url.URL); ok {
+		return requestURL
+	}
+
+	return &url.URL{}
+}
+
+// MIMEApplicationProblemJSON is the content type of the errors, which are RFC 7807 problem details.
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// StatusClientClosedRequest is the status of the requests the clients gave up on before they were served.
+// Nobody reads their responses, but the status tells them apart in the logs.
+const StatusClientClosedRequest = 499
+
+// HTTPErrorHandler responds to the errors returned by the operations and middlewares with a Problem
+// of the status and code they stand for. The errors are logged along with their causes, which are never
+// sent to the clients.
+func HTTPErrorHandler(err error, e echo.Context) {
+	if e.Response().Committed {
+		return
+	}
+
+	problem := createProblem(e.Request().Context(), err, e.Request().URL.Path)
+	e.Logger().Errorf("failed to %s %s with %d %s: %v", e.Request().Method, e.Request().URL.Path, problem.Status, problem.Code, err)
+	e.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	if err := e.JSON(problem.Status, problem); err != nil {
+		e.Logger().Errorf("failed to write error response: %v", err)
+	}
+}
+
+func createProblem(ctx context.Context, err error, instance string) api.Problem {
+	var badRequestErr models.BadRequestError
+	var notFoundErr models.NotFoundError
+	var conflictErr models.ConflictError
+	var unauthorizedErr models.UnauthorizedError
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &badRequestErr):
+		problem := newProblem(http.StatusBadRequest, badRequestErr.Code, badRequestErr.Msg, instance)
+		if len(badRequestErr.Fields) > 0 {
+			fields := make([]api.FieldError, len(badRequestErr.Fields))
+			for i, field := range badRequestErr.Fields {
+				fields[i] = api.FieldError{Field: field.Field, Message: field.Msg}
+			}
+			problem.Errors = &fields
+		}
+
+		return problem
+	case errors.As(err, &notFoundErr):
+		return newProblem(http.StatusNotFound, notFoundErr.Code, notFoundErr.Msg, instance)
+	case errors.As(err, &conflictErr):
+		return newProblem(http.StatusConflict, conflictErr.Code, conflictErr.Msg, instance)
+	case errors.As(err, &unauthorizedErr):
+		return newProblem(http.StatusUnauthorized, unauthorizedErr.Code, unauthorizedErr.Msg, instance)
+	// The queries fail with the context of the request once the client gives up on it.
+	case errors.Is(err, context.Canceled), errors.Is(ctx.Err(), context.Canceled):
+		return newProblem(StatusClientClosedRequest, models.RequestCanceledCode, "request canceled", instance)
+	case errors.Is(err, models.ErrTimeout), errors.Is(err, context.DeadlineExceeded), errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return newProblem(http.StatusGatewayTimeout, models.TimeoutErrorCode, "request timed out", instance)
+	case errors.Is(err, models.ErrUnavailable):
+		return newProblem(http.StatusServiceUnavailable, models.UnavailableErrorCode, "service unavailable", instance)
+	case errors.As(err, &httpErr):
+		// Echo reports unknown routes and methods, and bodies it can not bind, which are all client errors.
+		switch {
+		case httpErr.Code == http.StatusNotFound:
+			return newProblem(httpErr.Code, models.NotFoundErrorCode, fmt.Sprint(httpErr.Message), instance)
+		case httpErr.Code == http.StatusMethodNotAllowed:
+			return newProblem(httpErr.Code, models.MethodNotAllowedCode, fmt.Sprint(httpErr.Message), instance)
+		case httpErr.Code < http.StatusInternalServerError:
+			return newProblem(httpErr.Code, models.BadRequestErrorCode, fmt.Sprint(httpErr.Message), instance)
+		}
+	}
+
+	return newProblem(http.StatusInternalServerError, models.InternalErrorCode, "internal server error", instance)
+}
+
+// newProblem returns a Problem identified by its code, so its type is about:blank and its title
+// the one of its status, as RFC 7807 defines for those.
+func newProblem(status int, code, detail, instance string) api.Problem {
+	title := http.StatusText(status)
+	if status == StatusClientClosedRequest {
+		title = "Client Closed Request"
+	}
+
+	return api.Problem{
+		Type:     "about:blank",
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     api.ProblemCode(code),
+	}
+}
@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toshko07/outdoorsy-challenge/api"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+// serve handles the request like the application does, with its routes, validation and error handling,
+// also checking that the response matches the API spec.
+func serve(t *testing.T, server *Server, req *http.Request) *httptest.ResponseRecorder {
+	e := echo.New()
+	require.NoError(t, RegisterHandlers(e, server, ValidatorOptions{ValidateResponses: true}))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_InvalidRequests(t *testing.T) {
+	testCases := []struct {
+		name               string
+		method             string
+		target             string
+		expectedResponse   string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Unknown query parameter",
+			method:             http.MethodGet,
+			target:             "/v1/rentals?pirce_min=100",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"pirce_min\",\"message\":\"is not a known parameter\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown and invalid query parameters",
+			method:             http.MethodGet,
+			target:             "/v1/rentals?price_min=abc&near=33.64&pirce_max=100",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"pirce_max\",\"message\":\"is not a known parameter\"},{\"field\":\"price_min\",\"message\":\"must be an integer\"},{\"field\":\"near\",\"message\":\"must have exactly 2 items\"}],\"instance\":\"/v1/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown path",
+			method:             http.MethodGet,
+			target:             "/v1/campers",
+			expectedResponse:   "{\"code\":\"not_found\",\"detail\":\"Not Found\",\"instance\":\"/v1/campers\",\"status\":404,\"title\":\"Not Found\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Unknown method",
+			method:             http.MethodPost,
+			target:             "/v1/users/1",
+			expectedResponse:   "{\"code\":\"method_not_allowed\",\"detail\":\"Method Not Allowed\",\"instance\":\"/v1/users/1\",\"status\":405,\"title\":\"Method Not Allowed\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			server := NewServer(
+				NewRentalsController(services.NewMockRentals(ctrl)),
+				NewUsersController(services.NewMockUsers(ctrl)),
+				NewBookingsController(services.NewMockBookings(ctrl)),
+				NewPricingController(services.NewMockPricing(ctrl)),
+			)
+			req := httptest.NewRequest(tc.method, tc.target, nil)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestServer_ResponseNotMatchingSpec(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	service := services.NewMockRentals(ctrl)
+	service.EXPECT().GetRental(gomock.Any(), 1, "").Return(&models.Rental{Id: 1}, nil)
+	server := &Server{RentalsController: NewRentalsController(service)}
+	req := httptest.NewRequest(http.MethodGet, "/v1/rentals/1", nil)
+
+	// When
+	rec := serve(t, server, req)
+
+	// Then
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "{\"code\":\"internal_error\",\"detail\":\"internal server error\",\"instance\":\"/v1/rentals/1\",\"status\":500,\"title\":\"Internal Server Error\",\"type\":\"about:blank\"}\n", rec.Body.String())
+}
+
+func TestServer_RoutesEveryOperation(t *testing.T) {
+	// Given
+	spec, err := api.GetSwagger()
+	require.NoError(t, err)
+	e := echo.New()
+
+	// When
+	require.NoError(t, RegisterHandlers(e, &Server{}, ValidatorOptions{}))
+
+	// Then
+	routes := map[string]bool{}
+	for _, route := range e.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+	for path, pathItem := range spec.Paths {
+		for method := range pathItem.Operations() {
+			route := method + " " + strings.NewReplacer("{", ":", "}", "").Replace(path)
+			assert.True(t, routes[route], "%s is not routed", route)
+		}
+	}
+}
+
+func TestServer_ErrorResponses(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		expectedServiceError error
+		expectedResponse     string
+		expectedStatusCode   int
+	}{
+		{
+			name:                 "Wrapped not found error",
+			expectedServiceError: fmt.Errorf("failed to convert rental: %w", models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 1 not found")),
+			expectedResponse:     "{\"code\":\"rental_not_found\",\"detail\":\"rental with id 1 not found\",\"instance\":\"/v1/rentals/1\",\"status\":404,\"title\":\"Not Found\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusNotFound,
+		},
+		{
+			name:                 "Unauthorized error",
+			expectedServiceError: models.NewUnauthorizedError("missing credentials"),
+			expectedResponse:     "{\"code\":\"unauthorized\",\"detail\":\"missing credentials\",\"instance\":\"/v1/rentals/1\",\"status\":401,\"title\":\"Unauthorized\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusUnauthorized,
+		},
+		{
+			name:                 "Internal error does not leak its cause",
+			expectedServiceError: models.NewInternalError("failed to get rental", errors.New("pq: relation \"rentals\" does not exist")),
+			expectedResponse:     "{\"code\":\"internal_error\",\"detail\":\"internal server error\",\"instance\":\"/v1/rentals/1\",\"status\":500,\"title\":\"Internal Server Error\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusInternalServerError,
+		},
+		{
+			name:                 "Unavailable error",
+			expectedServiceError: models.NewUnavailableError("failed to get rental", errors.New("dial tcp 127.0.0.1:5432: connection refused")),
+			expectedResponse:     "{\"code\":\"unavailable\",\"detail\":\"service unavailable\",\"instance\":\"/v1/rentals/1\",\"status\":503,\"title\":\"Service Unavailable\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusServiceUnavailable,
+		},
+		{
+			name:                 "Timeout error",
+			expectedServiceError: models.NewTimeoutError("failed to get rental", errors.New("pq: canceling statement due to statement timeout")),
+			expectedResponse:     "{\"code\":\"timeout\",\"detail\":\"request timed out\",\"instance\":\"/v1/rentals/1\",\"status\":504,\"title\":\"Gateway Timeout\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusGatewayTimeout,
+		},
+		{
+			name:                 "Context deadline exceeded",
+			expectedServiceError: models.NewInternalError("failed to get rental", context.DeadlineExceeded),
+			expectedResponse:     "{\"code\":\"timeout\",\"detail\":\"request timed out\",\"instance\":\"/v1/rentals/1\",\"status\":504,\"title\":\"Gateway Timeout\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusGatewayTimeout,
+		},
+		{
+			name:                 "Context canceled",
+			expectedServiceError: models.NewInternalError("failed to get rental", context.Canceled),
+			expectedResponse:     "{\"code\":\"request_canceled\",\"detail\":\"request canceled\",\"instance\":\"/v1/rentals/1\",\"status\":499,\"title\":\"Client Closed Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   StatusClientClosedRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockRentals(ctrl)
+			service.EXPECT().GetRental(gomock.Any(), 1, "").Return(nil, tc.expectedServiceError)
+			server := &Server{RentalsController: NewRentalsController(service)}
+			req := httptest.NewRequest(http.MethodGet, "/v1/rentals/1", nil)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
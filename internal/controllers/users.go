@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/toshko07/outdoorsy-challenge/api"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/services"
+)
+
+type UsersController struct {
+	UsersService services.Users
+}
+
+func NewUsersController(usersService services.Users) *UsersController {
+	return &UsersController{
+		UsersService: usersService,
+	}
+}
+
+// Get User by id
+func (c *UsersController) GetUser(ctx context.Context, request api.GetUserRequestObject) (api.GetUserResponseObject, error) {
+	user, err := c.UsersService.GetUser(ctx, request.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetUser200JSONResponse(createUserResponse(*user)), nil
+}
+
+// Get the Rentals of a User by query, accepting the same queries as GetRentals
+func (c *UsersController) GetUserRentals(ctx context.Context, request api.GetUserRentalsRequestObject) (api.GetUserRentalsResponseObject, error) {
+	rentalsQueries, err := consumeQueryParams(api.GetRentalsParams(request.Params))
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := c.UsersService.GetUserRentals(ctx, request.UserId, rentalsQueries)
+	if err != nil {
+		return nil, err
+	}
+
+	return createRentalsPageResponse(ctx, *page, rentalsQueries.After != nil), nil
+}
+
+func createUserResponse(user models.User) api.User {
+	return api.User{
+		Id:        user.Id,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+	}
+}
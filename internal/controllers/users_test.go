@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsers_GetUser(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		id                      string
+		expectedServiceResponse *models.User
+		expectedServiceError    error
+		expectedResponse        string
+		expectedStatusCode      int
+	}{
+		{
+			name:                    "Get existing user",
+			id:                      "1",
+			expectedServiceResponse: &models.User{Id: 1, FirstName: "John", LastName: "Smith"},
+			expectedResponse:        "{\"first_name\":\"John\",\"id\":1,\"last_name\":\"Smith\"}\n",
+			expectedStatusCode:      http.StatusOK,
+		},
+		{
+			name:                 "Get non-existing user",
+			id:                   "404",
+			expectedServiceError: models.NewNotFoundError(models.UserNotFoundCode, "user with id 404 not found"),
+			expectedResponse:     "{\"code\":\"user_not_found\",\"detail\":\"user with id 404 not found\",\"instance\":\"/v1/users/404\",\"status\":404,\"title\":\"Not Found\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusNotFound,
+		},
+		{
+			name:               "Id is not a number",
+			id:                 "abc",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"user_id\",\"message\":\"must be an integer\"}],\"instance\":\"/v1/users/abc\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockUsers(ctrl)
+			if tc.expectedServiceResponse != nil || tc.expectedServiceError != nil {
+				service.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(tc.expectedServiceResponse, tc.expectedServiceError)
+			}
+			server := &Server{UsersController: NewUsersController(service)}
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/"+tc.id, nil)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestUsers_GetUserRentals(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		params               *models.GetRentalsParams
+		expectedServiceError error
+		expectedResponse     string
+		expectedStatusCode   int
+	}{
+		{
+			name:  "Get the rentals of an existing user",
+			query: "price_max=10000&near=33.64,-117.93&sort=distance",
+			params: &models.GetRentalsParams{
+				PriceMax: 10000,
+				Near:     []float64{33.64, -117.93},
+				Sort:     []models.SortField{{Field: models.SortFieldDistance}},
+			},
+			expectedResponse:   "[]\n",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:                 "Get the rentals of a non-existing user",
+			query:                "",
+			params:               &models.GetRentalsParams{},
+			expectedServiceError: models.NewNotFoundError(models.UserNotFoundCode, "user with id 4 not found"),
+			expectedResponse:     "{\"code\":\"user_not_found\",\"detail\":\"user with id 4 not found\",\"instance\":\"/v1/users/4/rentals\",\"status\":404,\"title\":\"Not Found\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode:   http.StatusNotFound,
+		},
+		{
+			name:               "Invalid query parameters",
+			query:              "price_min=abc",
+			expectedResponse:   "{\"code\":\"invalid_request\",\"detail\":\"invalid request\",\"errors\":[{\"field\":\"price_min\",\"message\":\"must be an integer\"}],\"instance\":\"/v1/users/4/rentals\",\"status\":400,\"title\":\"Bad Request\",\"type\":\"about:blank\"}\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			service := services.NewMockUsers(ctrl)
+			if tc.params != nil {
+				page := &models.RentalsPage{Limit: models.DefaultLimit}
+				if tc.expectedServiceError != nil {
+					page = nil
+				}
+				service.EXPECT().GetUserRentals(gomock.Any(), 4, *tc.params).Return(page, tc.expectedServiceError)
+			}
+			server := &Server{UsersController: NewUsersController(service)}
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/4/rentals?"+tc.query, nil)
+
+			// When
+			rec := serve(t, server, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/labstack/echo/v4"
+	"github.com/toshko07/outdoorsy-challenge/api"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+// ValidatorOptions configures which messages the validator checks against the API spec.
+type ValidatorOptions struct {
+	// ValidateResponses checks the responses too, replacing the ones not matching the spec by an internal error.
+	// Every response is buffered to do so, which is meant for tests.
+	ValidateResponses bool
+}
+
+// NewValidator returns a middleware rejecting the requests that do not match the API spec with a BadRequestError,
+// listing every invalid or undocumented parameter and every invalid field of the body.
+func NewValidator(options ValidatorOptions) (echo.MiddlewareFunc, error) {
+	spec, err := api.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the API spec: %w", err)
+	}
+
+	// Routes are matched by their path only, wherever the API is served from.
+	spec.Servers = nil
+	router, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to route the API spec: %w", err)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(e echo.Context) error {
+			route, pathParams, err := router.FindRoute(e.Request())
+			if err != nil {
+				// Echo itself responds to the paths and methods the API does not have.
+				return next(e)
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    e.Request(),
+				PathParams: pathParams,
+				Route:      route,
+				// The services apply their own defaults, the request keeps only what the client sent.
+				Options: &openapi3filter.Options{MultiError: true, SkipSettingDefaults: true},
+			}
+			if err := validateRequest(e.Request().Context(), input); err != nil {
+				return err
+			}
+
+			if !options.ValidateResponses {
+				return next(e)
+			}
+
+			return validateResponse(e, input, next)
+		}
+	}, nil
+}
+
+func validateRequest(ctx context.Context, input *openapi3filter.RequestValidationInput) error {
+	var fields []models.FieldError
+
+	// Undocumented parameters would be ignored silently, so a typo could return unfiltered rentals.
+	names := make([]string, 0, len(input.Request.URL.Query()))
+	for name := range input.Request.URL.Query() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !hasQueryParameter(input.Route, name) {
+			fields = append(fields, models.FieldError{Field: name, Msg: "is not a known parameter"})
+		}
+	}
+
+	if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+		fields = append(fields, requestFieldErrors(err)...)
+	}
+
+	if len(fields) > 0 {
+		return models.NewBadRequestError("invalid request", fields...)
+	}
+
+	return nil
+}
+
+func hasQueryParameter(route *routers.Route, name string) bool {
+	return route.Operation.Parameters.GetByInAndName(openapi3.ParameterInQuery, name) != nil ||
+		route.PathItem.Parameters.GetByInAndName(openapi3.ParameterInQuery, name) != nil
+}
+
+// requestFieldErrors converts the errors of the request validation into errors of the fields they are about.
+func requestFieldErrors(err error) []models.FieldError {
+	if multiErr, ok := err.(openapi3.MultiError); ok {
+		var fields []models.FieldError
+		for _, err := range multiErr {
+			fields = append(fields, requestFieldErrors(err)...)
+		}
+		return fields
+	}
+
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return []models.FieldError{{Field: "request", Msg: err.Error()}}
+	}
+
+	switch {
+	case requestErr.Parameter != nil:
+		return []models.FieldError{{Field: requestErr.Parameter.Name, Msg: requestErrorMessage(requestErr)}}
+	case requestErr.RequestBody != nil:
+		return bodyFieldErrors(requestErr)
+	default:
+		return []models.FieldError{{Field: "request", Msg: requestErr.Error()}}
+	}
+}
+
+// bodyFieldErrors names the fields of the body by their path, like price.day, or body when it is the whole body.
+func bodyFieldErrors(requestErr *openapi3filter.RequestError) []models.FieldError {
+	var multiErr openapi3.MultiError
+	if !errors.As(requestErr.Err, &multiErr) {
+		multiErr = openapi3.MultiError{requestErr.Err}
+	}
+
+	fields := make([]models.FieldError, 0, len(multiErr))
+	for _, err := range multiErr {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(err, &schemaErr) {
+			fields = append(fields, models.FieldError{Field: "body", Msg: bodyErrorMessage(requestErr)})
+			continue
+		}
+
+		field := strings.Join(schemaErr.JSONPointer(), ".")
+		if field == "" {
+			field = "body"
+		}
+		fields = append(fields, models.FieldError{Field: field, Msg: schemaErrorMessage(schemaErr)})
+	}
+
+	return fields
+}
+
+// requestErrorMessage returns why a parameter is invalid, worded like the rest of the API errors.
+func requestErrorMessage(requestErr *openapi3filter.RequestError) string {
+	var schemaErr *openapi3.SchemaError
+	var parseErr *openapi3filter.ParseError
+	switch {
+	case errors.Is(requestErr.Err, openapi3filter.ErrInvalidRequired):
+		return "is required"
+	case errors.Is(requestErr.Err, openapi3filter.ErrInvalidEmptyValue):
+		return "must not be empty"
+	case errors.As(requestErr.Err, &schemaErr):
+		return schemaErrorMessage(schemaErr)
+	case errors.As(requestErr.Err, &parseErr):
+		return parseErrorMessage(requestErr.Parameter.Schema)
+	case requestErr.Err != nil:
+		return requestErr.Err.Error()
+	default:
+		return requestErr.Reason
+	}
+}
+
+// parseErrorMessage returns what a parameter which could not be parsed should have been.
+func parseErrorMessage(schema *openapi3.SchemaRef) string {
+	if schema == nil || schema.Value == nil {
+		return "is invalid"
+	}
+
+	if schema.Value.Type == openapi3.TypeArray && schema.Value.Items != nil && schema.Value.Items.Value != nil {
+		switch schema.Value.Items.Value.Type {
+		case openapi3.TypeInteger:
+			return "must be a comma separated list of integers"
+		case openapi3.TypeNumber:
+			return "must be a comma separated list of numbers"
+		}
+		return "must be a comma separated list without empty values"
+	}
+
+	switch schema.Value.Type {
+	case openapi3.TypeInteger:
+		return "must be an integer"
+	case openapi3.TypeNumber:
+		return "must be a number"
+	case openapi3.TypeBoolean:
+		return "must be true or false"
+	}
+	return "is invalid"
+}
+
+func bodyErrorMessage(requestErr *openapi3filter.RequestError) string {
+	if errors.Is(requestErr.Err, openapi3filter.ErrInvalidRequired) {
+		return "is required"
+	}
+
+	return "must be a JSON object"
+}
+
+// schemaErrorMessage returns which constraint of the schema a value breaks.
+func schemaErrorMessage(schemaErr *openapi3.SchemaError) string {
+	schema := schemaErr.Schema
+	switch schemaErr.SchemaField {
+	case "required":
+		return "is required"
+	case "type":
+		switch schema.Type {
+		case openapi3.TypeInteger:
+			return "must be an integer"
+		case openapi3.TypeArray:
+			return "must be an array"
+		case openapi3.TypeObject:
+			return "must be an object"
+		default:
+			return fmt.Sprintf("must be a %s", schema.Type)
+		}
+	case "format":
+		if schema.Format == "date" {
+			return "must be a date formatted as YYYY-MM-DD"
+		}
+		return fmt.Sprintf("must be formatted as %s", schema.Format)
+	case "enum":
+		values := make([]string, len(schema.Enum))
+		for i, value := range schema.Enum {
+			values[i] = fmt.Sprintf("'%v'", value)
+		}
+		return fmt.Sprintf("must be one of %s", strings.Join(values, ", "))
+	case "minimum", "exclusiveMinimum":
+		if schema.Min == nil {
+			break
+		}
+		if schema.Max != nil && !schema.ExclusiveMin && !schema.ExclusiveMax {
+			return fmt.Sprintf("must be between %v and %v", *schema.Min, *schema.Max)
+		}
+		if schema.ExclusiveMin {
+			return fmt.Sprintf("must be greater than %v", *schema.Min)
+		}
+		if *schema.Min == 0 {
+			return "must not be negative"
+		}
+		return fmt.Sprintf("must be at least %v", *schema.Min)
+	case "maximum", "exclusiveMaximum":
+		if schema.Max == nil {
+			break
+		}
+		if schema.Min != nil && !schema.ExclusiveMin && !schema.ExclusiveMax {
+			return fmt.Sprintf("must be between %v and %v", *schema.Min, *schema.Max)
+		}
+		if schema.ExclusiveMax {
+			return fmt.Sprintf("must be less than %v", *schema.Max)
+		}
+		return fmt.Sprintf("must be at most %v", *schema.Max)
+	case "minLength":
+		if schema.MinLength == 1 {
+			return "must not be empty"
+		}
+		return fmt.Sprintf("must be at least %d characters long", schema.MinLength)
+	case "maxLength":
+		if schema.MaxLength != nil {
+			return fmt.Sprintf("must not be longer than %d characters", *schema.MaxLength)
+		}
+	case "minItems", "maxItems":
+		if schema.MaxItems != nil && *schema.MaxItems == schema.MinItems {
+			return fmt.Sprintf("must have exactly %d items", schema.MinItems)
+		}
+		if schemaErr.SchemaField == "minItems" {
+			return fmt.Sprintf("must have at least %d items", schema.MinItems)
+		}
+		if schema.MaxItems != nil {
+			return fmt.Sprintf("must have at most %d items", *schema.MaxItems)
+		}
+	case "pattern":
+		return fmt.Sprintf("must match %s", schema.Pattern)
+	}
+
+	return schemaErr.Reason
+}
+
+// bufferedResponse holds a response back until it has been validated.
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *bufferedResponse) Header() http.Header {
+	return r.header
+}
+
+func (r *bufferedResponse) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *bufferedResponse) Write(data []byte) (int, error) {
+	return r.body.Write(data)
+}
+
+func validateResponse(e echo.Context, input *openapi3filter.RequestValidationInput, next echo.HandlerFunc) error {
+	response := e.Response()
+	writer := response.Writer
+	buffered := &bufferedResponse{header: writer.Header(), status: http.StatusOK}
+	response.Writer = buffered
+	if err := next(e); err != nil {
+		e.Error(err)
+	}
+	response.Writer = writer
+
+	err := openapi3filter.ValidateResponse(e.Request().Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 buffered.status,
+		Header:                 buffered.header,
+		Body:                   io.NopCloser(bytes.NewReader(buffered.body.Bytes())),
+		Options:                &openapi3filter.Options{MultiError: true, IncludeResponseStatus: true},
+	})
+	if err != nil {
+		for name := range buffered.header {
+			buffered.header.Del(name)
+		}
+		response.Committed, response.Size = false, 0
+		return models.NewInternalError("response does not match the API spec", err)
+	}
+
+	writer.WriteHeader(buffered.status)
+	_, err = writer.Write(buffered.body.Bytes())
+	return err
+}
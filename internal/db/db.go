@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
+	"net"
 	"net/url"
 	"os"
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/labstack/gommon/log"
@@ -16,15 +18,48 @@ import (
 	"github.com/toshko07/outdoorsy-challenge/internal/configs"
 )
 
+// The waits between the pings of the database on startup, doubling up to the max.
+const (
+	connectInitialBackoff = 500 * time.Millisecond
+	connectMaxBackoff     = 10 * time.Second
+)
+
 func Connect(config configs.DB) *sql.DB {
 	log.Info("initializing database connection ...")
 	database := newDBConnection(config)
-	if err := database.Ping(); err != nil {
+	if err := ping(database, config); err != nil {
 		log.Fatalf("failed to ping database: %v", err)
 	}
 	return database
 }
 
+// ping pings the database until it answers, as it may still be starting along with the application,
+// backing off between the attempts.
+func ping(database *sql.DB, config configs.DB) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), config.ConnectTimeout)
+		err = database.PingContext(ctx)
+		cancel()
+		if err == nil || attempt >= config.ConnectAttempts {
+			return err
+		}
+
+		backoff := connectBackoff(attempt)
+		log.Warnf("failed to ping database, attempt %d of %d, retrying in %s: %v", attempt, config.ConnectAttempts, backoff, err)
+		time.Sleep(backoff)
+	}
+}
+
+// connectBackoff returns the wait after the failed attempt, counted from 1.
+func connectBackoff(attempt int) time.Duration {
+	backoff := connectInitialBackoff
+	for i := 1; i < attempt && backoff < connectMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, connectMaxBackoff)
+}
+
 func SetupTestDb() (*sql.DB, func()) {
 	// Create the Postgres TestContainer
 	ctx := context.Background()
@@ -66,6 +101,14 @@ func SetupTestDb() (*sql.DB, func()) {
 		panic(err)
 	}
 
+	migrator, err := NewMigrator(testDB)
+	if err != nil {
+		panic(err)
+	}
+	if err := migrator.Up(ctx); err != nil {
+		panic(err)
+	}
+
 	return testDB, func() {
 		if err := postgresC.Terminate(ctx); err != nil {
 			panic(err)
@@ -73,26 +116,17 @@ func SetupTestDb() (*sql.DB, func()) {
 	}
 }
 
+// SetupTestData empties the tables the tests write to and loads the test data into them.
 func SetupTestData(database *sql.DB) {
 	_, err := database.Exec(`
-	CREATE OR REPLACE FUNCTION truncate_tables(username IN VARCHAR) RETURNS void AS $$
-	DECLARE
-		statements CURSOR FOR
-			SELECT tablename FROM pg_tables
-			WHERE tableowner = username AND schemaname = 'public';
-	BEGIN
-		FOR stmt IN statements LOOP
-			EXECUTE 'TRUNCATE TABLE ' || quote_ident(stmt.tablename) || ' CASCADE;';
-		END LOOP;
-	END;
-	$$ LANGUAGE plpgsql;
-	SELECT truncate_tables('postgres');
+	TRUNCATE TABLE users, rentals, bookings, blocked_dates, rental_pricing, seasonal_prices, tax_rates
+	RESTART IDENTITY CASCADE;
 	`)
 	if err != nil {
 		panic(err)
 	}
 
-	err = loadTestData(database, "../db/test_data/sql-init.sql")
+	err = loadTestData(database, "../db/test_data/seed.sql")
 	if err != nil {
 		panic(err)
 	}
@@ -103,14 +137,9 @@ func loadTestData(database *sql.DB, path string) error {
 	if err != nil {
 		return err
 	}
-	requests := strings.Split(string(file), ";")
-	for _, request := range requests {
-		_, err := database.Exec(request)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	// The file runs as a single multi-statement query, so function bodies can contain semicolons.
+	_, err = database.Exec(string(file))
+	return err
 }
 
 func newDBConnection(config configs.DB) *sql.DB {
@@ -118,16 +147,43 @@ func newDBConnection(config configs.DB) *sql.DB {
 	if err != nil {
 		log.Fatalf("failed to open database connection: %v", err)
 	}
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
 
 	log.Infof("successfully connected to database: %s", config.Name)
 	return db
 }
 
 func buildDBConnectionString(config configs.DB) string {
-	host := config.Host
-	encodedPassword := url.QueryEscape(config.Password)
-	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		config.Username, encodedPassword,
-		host, config.Port, config.Name,
-		"disable")
+	params := url.Values{}
+	params.Set("sslmode", config.SSLMode)
+	if config.SSLRootCert != "" {
+		params.Set("sslrootcert", config.SSLRootCert)
+	}
+	if config.SSLCert != "" {
+		params.Set("sslcert", config.SSLCert)
+	}
+	if config.SSLKey != "" {
+		params.Set("sslkey", config.SSLKey)
+	}
+	if config.ConnectTimeout > 0 {
+		// Rounded up, as 0 would wait forever.
+		params.Set("connect_timeout", strconv.Itoa(int(math.Ceil(config.ConnectTimeout.Seconds()))))
+	}
+	if config.ApplicationName != "" {
+		params.Set("application_name", config.ApplicationName)
+	}
+	// The parameters unknown to the driver are set on every connection, like SET statement_timeout would.
+	params.Set("statement_timeout", strconv.FormatInt(config.StatementTimeout.Milliseconds(), 10))
+
+	connectionURL := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(config.Username, config.Password),
+		Host:     net.JoinHostPort(config.Host, config.Port),
+		Path:     config.Name,
+		RawQuery: params.Encode(),
+	}
+	return connectionURL.String()
 }
@@ -0,0 +1,246 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	testCases := []struct {
+		name               string
+		files              fstest.MapFS
+		expectedMigrations []Migration
+		expectedError      string
+	}{
+		{
+			name: "Sorted by version",
+			files: fstest.MapFS{
+				"migrations/0010_add_rentals_search.up.sql":       {Data: []byte("ALTER TABLE rentals ADD COLUMN search tsvector;")},
+				"migrations/0010_add_rentals_search.down.sql":     {Data: []byte("ALTER TABLE rentals DROP COLUMN search;")},
+				"migrations/0002_create_rentals.up.sql":           {Data: []byte("CREATE TABLE rentals (id SERIAL);")},
+				"migrations/0002_create_rentals.down.sql":         {Data: []byte("DROP TABLE rentals;")},
+				"migrations/0001_create_users.up.sql":             {Data: []byte("CREATE TABLE users (id SERIAL);")},
+				"migrations/0001_create_users.down.sql":           {Data: []byte("DROP TABLE users;")},
+				"migrations/README.md":                            {Data: []byte("ignored")},
+				"migrations/nested/0003_ignored_migration.up.sql": {Data: []byte("ignored")},
+			},
+			expectedMigrations: []Migration{
+				{Version: 1, Name: "create_users", Up: "CREATE TABLE users (id SERIAL);", Down: "DROP TABLE users;"},
+				{Version: 2, Name: "create_rentals", Up: "CREATE TABLE rentals (id SERIAL);", Down: "DROP TABLE rentals;"},
+				{Version: 10, Name: "add_rentals_search", Up: "ALTER TABLE rentals ADD COLUMN search tsvector;", Down: "ALTER TABLE rentals DROP COLUMN search;"},
+			},
+		},
+		{
+			name: "Missing down file",
+			files: fstest.MapFS{
+				"migrations/0001_create_users.up.sql": {Data: []byte("CREATE TABLE users (id SERIAL);")},
+			},
+			expectedError: "migration 1 create_users needs both an up and a down file",
+		},
+		{
+			name: "Invalid file name",
+			files: fstest.MapFS{
+				"migrations/create_users.up.sql": {Data: []byte("CREATE TABLE users (id SERIAL);")},
+			},
+			expectedError: "invalid migration file name 'create_users.up.sql'",
+		},
+		{
+			name: "Version 0",
+			files: fstest.MapFS{
+				"migrations/0000_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id SERIAL);")},
+				"migrations/0000_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+			},
+			expectedError: "invalid migration file name '0000_create_users.down.sql': the versions start at 1",
+		},
+		{
+			name: "Same version with different names",
+			files: fstest.MapFS{
+				"migrations/0001_create_users.up.sql":    {Data: []byte("CREATE TABLE users (id SERIAL);")},
+				"migrations/0001_create_people.down.sql": {Data: []byte("DROP TABLE people;")},
+			},
+			expectedError: "migration 1 is named both 'create_people' and 'create_users'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// When
+			migrations, err := loadMigrations(tc.files)
+
+			// Then
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedMigrations, migrations)
+		})
+	}
+}
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	// When
+	migrations, err := loadMigrations(migrationFiles)
+
+	// Then
+	require.NoError(t, err)
+	for i, migration := range migrations {
+		assert.Equal(t, i+1, migration.Version, "the versions of the migrations must not have gaps")
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	database, shutdown := SetupTestDb()
+	defer shutdown()
+	migrator, err := NewMigrator(database)
+	require.NoError(t, err)
+
+	t.Run("Up migrates to the latest version", func(t *testing.T) {
+		// When
+		version, err := migrator.Version(ctx)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, migrator.LatestVersion(), version)
+	})
+
+	t.Run("Down reverts the latest migration", func(t *testing.T) {
+		// When
+		err := migrator.Down(ctx)
+
+		// Then
+		assert.NoError(t, err)
+		version, err := migrator.Version(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, migrator.LatestVersion()-1, version)
+	})
+
+	t.Run("To 0 reverts every migration", func(t *testing.T) {
+		// When
+		err := migrator.To(ctx, 0)
+
+		// Then
+		assert.NoError(t, err)
+		statuses, err := migrator.Status(ctx)
+		assert.NoError(t, err)
+		for _, status := range statuses {
+			assert.Nil(t, status.AppliedAt, "migration %d must be pending", status.Version)
+		}
+		var tables int
+		err = database.QueryRow(`SELECT count(*) FROM pg_tables WHERE schemaname = 'public' AND tablename <> 'schema_migrations' AND tablename <> 'spatial_ref_sys'`).Scan(&tables)
+		assert.NoError(t, err)
+		assert.Zero(t, tables)
+	})
+
+	t.Run("To applies the migrations up to the version", func(t *testing.T) {
+		// When
+		err := migrator.To(ctx, 2)
+
+		// Then
+		assert.NoError(t, err)
+		statuses, err := migrator.Status(ctx)
+		assert.NoError(t, err)
+		for _, status := range statuses {
+			assert.Equal(t, status.Version <= 2, status.AppliedAt != nil, "migration %d", status.Version)
+		}
+	})
+
+	t.Run("To an unknown version", func(t *testing.T) {
+		// When
+		version := migrator.LatestVersion() + 1
+		err := migrator.To(ctx, version)
+
+		// Then
+		assert.EqualError(t, err, fmt.Sprintf("unknown migration version %d", version))
+	})
+
+	t.Run("Status does not wait for the migrations running", func(t *testing.T) {
+		// Given
+		holder, err := database.Conn(ctx)
+		require.NoError(t, err)
+		defer holder.Close()
+		_, err = holder.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationsLockKey)
+		require.NoError(t, err)
+		defer holder.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationsLockKey)
+		statusCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		// When
+		statuses, err := migrator.Status(statusCtx)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Len(t, statuses, migrator.LatestVersion())
+	})
+
+	t.Run("Concurrent migrators take turns", func(t *testing.T) {
+		// When
+		var wg sync.WaitGroup
+		errs := make([]error, 5)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = migrator.Up(ctx)
+			}(i)
+		}
+		wg.Wait()
+
+		// Then
+		for _, err := range errs {
+			assert.NoError(t, err)
+		}
+		version, err := migrator.Version(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, migrator.LatestVersion(), version)
+	})
+
+	t.Run("Migrators wait for the lock past the statement timeout", func(t *testing.T) {
+		// Given
+		_, err := database.ExecContext(ctx, `ALTER ROLE CURRENT_USER SET statement_timeout = '100ms'`)
+		require.NoError(t, err)
+		defer database.ExecContext(ctx, `ALTER ROLE CURRENT_USER RESET statement_timeout`)
+		// The connections opened from now on start with the timeout.
+		database.SetMaxIdleConns(0)
+		defer database.SetMaxIdleConns(2)
+		err = migrator.To(ctx, migrator.LatestVersion()-1)
+		require.NoError(t, err)
+		holder, err := database.Conn(ctx)
+		require.NoError(t, err)
+		defer holder.Close()
+		_, err = holder.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationsLockKey)
+		require.NoError(t, err)
+
+		// When
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = migrator.Up(ctx)
+			}(i)
+		}
+		time.Sleep(500 * time.Millisecond)
+		_, err = holder.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationsLockKey)
+		require.NoError(t, err)
+		wg.Wait()
+
+		// Then
+		for _, err := range errs {
+			assert.NoError(t, err)
+		}
+		version, err := migrator.Version(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, migrator.LatestVersion(), version)
+	})
+}
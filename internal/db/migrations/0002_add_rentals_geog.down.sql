@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS rentals_geog_idx;
+
+ALTER TABLE rentals DROP COLUMN IF EXISTS geog;
@@ -0,0 +1,14 @@
+-- Without PostGIS the rentals keep only their lat and lng, which the searches fall back to.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'postgis') THEN
+        CREATE EXTENSION IF NOT EXISTS postgis;
+
+        ALTER TABLE rentals ADD COLUMN IF NOT EXISTS geog geography(Point, 4326);
+
+        UPDATE rentals SET geog = ST_SetSRID(ST_MakePoint(lng, lat), 4326)::geography WHERE geog IS NULL;
+
+        CREATE INDEX IF NOT EXISTS rentals_geog_idx ON rentals USING GIST (geog);
+    END IF;
+END
+$$;
@@ -0,0 +1,3 @@
+DROP VIEW IF EXISTS unavailable_dates;
+DROP TABLE IF EXISTS blocked_dates;
+DROP TABLE IF EXISTS bookings;
@@ -0,0 +1,27 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE IF NOT EXISTS bookings (
+    id SERIAL PRIMARY KEY,
+    rental_id integer NOT NULL REFERENCES rentals (id) ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users (id),
+    dates daterange NOT NULL CHECK (NOT isempty(dates)),
+    status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'cancelled')),
+    total bigint NOT NULL,
+    created timestamp with time zone NOT NULL DEFAULT now(),
+    updated timestamp with time zone NOT NULL DEFAULT now(),
+    EXCLUDE USING GIST (rental_id WITH =, dates WITH &&) WHERE (status <> 'cancelled')
+);
+
+CREATE TABLE IF NOT EXISTS blocked_dates (
+    id SERIAL PRIMARY KEY,
+    rental_id integer NOT NULL REFERENCES rentals (id) ON DELETE CASCADE,
+    dates daterange NOT NULL CHECK (NOT isempty(dates)),
+    created timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS blocked_dates_rental_id_dates_idx ON blocked_dates USING GIST (rental_id, dates);
+
+CREATE OR REPLACE VIEW unavailable_dates AS
+    SELECT rental_id, dates, 'booked' AS reason FROM bookings WHERE status <> 'cancelled'
+    UNION ALL
+    SELECT rental_id, dates, 'blocked' AS reason FROM blocked_dates;
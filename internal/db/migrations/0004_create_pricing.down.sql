@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tax_rates;
+DROP TABLE IF EXISTS seasonal_prices;
+DROP TABLE IF EXISTS rental_pricing;
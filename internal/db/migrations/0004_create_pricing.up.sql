@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS rental_pricing (
+    rental_id integer PRIMARY KEY REFERENCES rentals (id) ON DELETE CASCADE,
+    weekend_per_day bigint CHECK (weekend_per_day > 0),
+    weekly_discount_percent integer NOT NULL DEFAULT 0 CHECK (weekly_discount_percent BETWEEN 0 AND 100),
+    monthly_discount_percent integer NOT NULL DEFAULT 0 CHECK (monthly_discount_percent BETWEEN 0 AND 100),
+    cleaning_fee bigint NOT NULL DEFAULT 0 CHECK (cleaning_fee >= 0),
+    extra_guest_per_day bigint NOT NULL DEFAULT 0 CHECK (extra_guest_per_day >= 0),
+    min_nights integer NOT NULL DEFAULT 1 CHECK (min_nights > 0)
+);
+
+CREATE TABLE IF NOT EXISTS seasonal_prices (
+    id SERIAL PRIMARY KEY,
+    rental_id integer NOT NULL REFERENCES rentals (id) ON DELETE CASCADE,
+    dates daterange NOT NULL CHECK (NOT isempty(dates)),
+    per_day bigint NOT NULL CHECK (per_day > 0),
+    EXCLUDE USING GIST (rental_id WITH =, dates WITH &&)
+);
+
+CREATE TABLE IF NOT EXISTS tax_rates (
+    country text NOT NULL,
+    state text NOT NULL,
+    rate_basis_points integer NOT NULL CHECK (rate_basis_points >= 0),
+    PRIMARY KEY (country, state)
+);
@@ -0,0 +1,12 @@
+ALTER TABLE rentals ADD COLUMN IF NOT EXISTS currency text NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$');
+
+-- The rentals created before the currencies were priced in the currency of their country.
+UPDATE rentals
+SET currency = CASE home_country
+    WHEN 'CA' THEN 'CAD'
+    WHEN 'GB' THEN 'GBP'
+    WHEN 'IE' THEN 'EUR'
+    WHEN 'AU' THEN 'AUD'
+    ELSE 'USD'
+END
+;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS rentals_vehicle_model_trgm_idx;
+DROP INDEX IF EXISTS rentals_vehicle_make_trgm_idx;
+DROP INDEX IF EXISTS rentals_search_idx;
+
+DROP TRIGGER IF EXISTS rentals_search_update ON rentals;
+DROP FUNCTION IF EXISTS rentals_search_update();
+
+ALTER TABLE rentals DROP COLUMN IF EXISTS search;
+
+DROP TABLE IF EXISTS vehicle_make_aliases;
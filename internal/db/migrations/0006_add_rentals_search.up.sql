@@ -0,0 +1,42 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Makes known by more than one name, so searching either name finds the rentals of both.
+CREATE TABLE IF NOT EXISTS vehicle_make_aliases (
+    make text NOT NULL,
+    alias text NOT NULL,
+    PRIMARY KEY (make, alias)
+);
+
+INSERT INTO vehicle_make_aliases (make, alias)
+VALUES
+('volkswagen', 'vw'),
+('chevrolet', 'chevy'),
+('mercedes-benz', 'mercedes')
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE rentals ADD COLUMN IF NOT EXISTS search tsvector;
+
+-- The name weighs the most, followed by the make and model, including the aliases of the make, and the description.
+CREATE OR REPLACE FUNCTION rentals_search_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search :=
+        setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.vehicle_make, '') || ' ' || coalesce(NEW.vehicle_model, '') || ' ' || coalesce((
+            SELECT string_agg(a.make || ' ' || a.alias, ' ')
+            FROM vehicle_make_aliases AS a
+            WHERE lower(NEW.vehicle_make) IN (a.make, a.alias)
+        ), '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.description, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS rentals_search_update ON rentals;
+CREATE TRIGGER rentals_search_update BEFORE INSERT OR UPDATE OF name, description, vehicle_make, vehicle_model ON rentals
+FOR EACH ROW EXECUTE PROCEDURE rentals_search_update();
+
+UPDATE rentals SET name = name WHERE search IS NULL;
+
+CREATE INDEX IF NOT EXISTS rentals_search_idx ON rentals USING GIN (search);
+CREATE INDEX IF NOT EXISTS rentals_vehicle_make_trgm_idx ON rentals USING GIN (vehicle_make gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS rentals_vehicle_model_trgm_idx ON rentals USING GIN (vehicle_model gin_trgm_ops);
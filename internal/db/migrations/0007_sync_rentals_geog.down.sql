@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS rentals_geog_update ON rentals;
+DROP FUNCTION IF EXISTS rentals_geog_update();
@@ -0,0 +1,19 @@
+-- Keeps the geog of the rentals at their lat and lng, which PostgreSQL 11 has no generated columns for.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'rentals' AND column_name = 'geog') THEN
+        CREATE OR REPLACE FUNCTION rentals_geog_update() RETURNS trigger AS $f$
+        BEGIN
+            NEW.geog := ST_SetSRID(ST_MakePoint(NEW.lng, NEW.lat), 4326)::geography;
+            RETURN NEW;
+        END
+        $f$ LANGUAGE plpgsql;
+
+        DROP TRIGGER IF EXISTS rentals_geog_update ON rentals;
+        CREATE TRIGGER rentals_geog_update BEFORE INSERT OR UPDATE OF lat, lng ON rentals
+        FOR EACH ROW EXECUTE PROCEDURE rentals_geog_update();
+
+        UPDATE rentals SET lat = lat WHERE geog IS NULL;
+    END IF;
+END
+$$;
@@ -0,0 +1,9 @@
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS currency text CHECK (currency ~ '^[A-Z]{3}$');
+
+-- The bookings were priced in the currency of their rental.
+UPDATE bookings AS b
+SET currency = r.currency
+FROM rentals AS r
+WHERE r.id = b.rental_id AND b.currency IS NULL;
+
+ALTER TABLE bookings ALTER COLUMN currency SET NOT NULL;
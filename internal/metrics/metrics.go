@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+// unmatchedRoute labels the requests to paths the server has no route for, which would otherwise add a label
+// value for every path a client makes up.
+const unmatchedRoute = "unmatched"
+
+// The search filters the searches are counted by.
+const (
+	SearchFilterNear  = "near"
+	SearchFilterPrice = "price"
+	SearchFilterIds   = "ids"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of the served HTTP requests, by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of the served HTTP requests, by method, route template and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Latency of the database queries, by the query they run.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"query"})
+
+	rentalSearches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rental_searches_total",
+		Help: "Number of the rental searches, by the filters they use. A search using several filters counts for each.",
+	}, []string{"filter"})
+)
+
+// Middleware records the count and latency of the requests, labelled by the template of the route they match,
+// like /v1/rentals/:id, instead of their path.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(e echo.Context) error {
+			start := time.Now()
+			err := next(e)
+			// The error is only responded to once the middlewares return, so the status would not be known yet.
+			if err != nil {
+				e.Error(err)
+			}
+
+			route := e.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+			status := strconv.Itoa(e.Response().Status)
+			httpRequests.WithLabelValues(e.Request().Method, route, status).Inc()
+			httpRequestDuration.WithLabelValues(e.Request().Method, route, status).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
+
+// ObserveQuery starts timing the query, and records its latency once the returned function is called:
+//
+//	defer metrics.ObserveQuery("get_rental")()
+func ObserveQuery(query string) func() {
+	start := time.Now()
+	return func() {
+		dbQueryDuration.WithLabelValues(query).Observe(time.Since(start).Seconds())
+	}
+}
+
+// CountSearch counts the search once for each of the near, price and ids filters it uses.
+func CountSearch(params models.GetRentalsParams) {
+	if len(params.Near) > 0 {
+		rentalSearches.WithLabelValues(SearchFilterNear).Inc()
+	}
+	if params.PriceMin > 0 || params.PriceMax > 0 {
+		rentalSearches.WithLabelValues(SearchFilterPrice).Inc()
+	}
+	if len(params.Ids) > 0 {
+		rentalSearches.WithLabelValues(SearchFilterIds).Inc()
+	}
+}
+
+// RegisterDBStats exports the statistics of the connection pool of the database, like its open and idle connections.
+func RegisterDBStats(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterHandlers routes the metrics to /metrics.
+func RegisterHandlers(e *echo.Echo) {
+	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+func TestMiddleware(t *testing.T) {
+	testCases := []struct {
+		name               string
+		target             string
+		expectedRoute      string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Labelled by route template",
+			target:             "/v1/rentals/1",
+			expectedRoute:      "/v1/rentals/:id",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Error responded by the error handler",
+			target:             "/v1/rentals/404",
+			expectedRoute:      "/v1/rentals/:id",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Unknown path",
+			target:             "/v1/unknown/1",
+			expectedRoute:      unmatchedRoute,
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			e := echo.New()
+			e.Use(Middleware())
+			e.GET("/v1/rentals/:id", func(e echo.Context) error {
+				if e.Param("id") == "404" {
+					return echo.NewHTTPError(http.StatusNotFound)
+				}
+				return e.NoContent(http.StatusOK)
+			})
+			requests := httpRequests.WithLabelValues(http.MethodGet, tc.expectedRoute, strconv.Itoa(tc.expectedStatusCode))
+			requestsBefore := testutil.ToFloat64(requests)
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			rec := httptest.NewRecorder()
+
+			// When
+			e.ServeHTTP(rec, req)
+
+			// Then
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, requestsBefore+1, testutil.ToFloat64(requests))
+		})
+	}
+}
+
+func TestCountSearch(t *testing.T) {
+	testCases := []struct {
+		name            string
+		params          models.GetRentalsParams
+		expectedCounted []string
+		expectedSkipped []string
+	}{
+		{
+			name:            "Without filters",
+			params:          models.GetRentalsParams{Limit: 20},
+			expectedSkipped: []string{SearchFilterNear, SearchFilterPrice, SearchFilterIds},
+		},
+		{
+			name:            "Near with a price",
+			params:          models.GetRentalsParams{Near: []float64{33.64, -117.93}, PriceMax: 10000},
+			expectedCounted: []string{SearchFilterNear, SearchFilterPrice},
+			expectedSkipped: []string{SearchFilterIds},
+		},
+		{
+			name:            "Ids",
+			params:          models.GetRentalsParams{Ids: []int{1, 2}},
+			expectedCounted: []string{SearchFilterIds},
+			expectedSkipped: []string{SearchFilterNear, SearchFilterPrice},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			before := map[string]float64{}
+			for _, filter := range []string{SearchFilterNear, SearchFilterPrice, SearchFilterIds} {
+				before[filter] = testutil.ToFloat64(rentalSearches.WithLabelValues(filter))
+			}
+
+			// When
+			CountSearch(tc.params)
+
+			// Then
+			for _, filter := range tc.expectedCounted {
+				assert.Equal(t, before[filter]+1, testutil.ToFloat64(rentalSearches.WithLabelValues(filter)), filter)
+			}
+			for _, filter := range tc.expectedSkipped {
+				assert.Equal(t, before[filter], testutil.ToFloat64(rentalSearches.WithLabelValues(filter)), filter)
+			}
+		})
+	}
+}
+
+func TestObserveQuery(t *testing.T) {
+	// Given
+	observe := ObserveQuery("get_rental")
+
+	// When
+	observe()
+
+	// Then
+	assert.Equal(t, 1, testutil.CollectAndCount(dbQueryDuration, "db_query_duration_seconds"))
+}
+
+func TestRegisterHandlers(t *testing.T) {
+	// Given
+	e := echo.New()
+	RegisterHandlers(e)
+	CountSearch(models.GetRentalsParams{Ids: []int{1}})
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "rental_searches_total{filter=\"ids\"}")
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// DateLayout is the format of the dates accepted and returned by the API.
+const DateLayout = "2006-01-02"
+
+// MaxAvailabilityDays is the longest range the availability of a rental can be requested for.
+const MaxAvailabilityDays = 366
+
+// DateRange is a half-open range of days, from Start up to but excluding End, like a stay from check-in to check-out.
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Days returns the number of days in the range, which is the number of nights of a stay.
+func (d DateRange) Days() int {
+	return int(d.End.Sub(d.Start).Hours() / 24)
+}
+
+const (
+	UnavailableReasonBooked  = "booked"
+	UnavailableReasonBlocked = "blocked"
+)
+
+// UnavailablePeriod is a range of days in which a rental is booked or blocked by its owner.
+type UnavailablePeriod struct {
+	DateRange
+	Reason string
+}
+
+// Availability of a rental within the requested range of days.
+type Availability struct {
+	RentalId int
+	DateRange
+	// Unavailable lists the periods within the range the rental can not be booked for, ordered by start.
+	Unavailable []UnavailablePeriod
+}
+
+// Available reports whether the rental can be booked for the whole range.
+func (a Availability) Available() bool {
+	return len(a.Unavailable) == 0
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+const (
+	BookingStatusPending   = "pending"
+	BookingStatusConfirmed = "confirmed"
+	BookingStatusCancelled = "cancelled"
+)
+
+// bookingTransitions lists the statuses each booking status can change to, a cancelled booking can not change anymore.
+var bookingTransitions = map[string][]string{
+	BookingStatusPending:   {BookingStatusConfirmed, BookingStatusCancelled},
+	BookingStatusConfirmed: {BookingStatusCancelled},
+	BookingStatusCancelled: {},
+}
+
+// Booking of a rental by a user, for the nights from Start up to the check-out day End.
+type Booking struct {
+	Id       int
+	RentalId int
+	UserId   int
+	DateRange
+	Status string
+	// Total is the price of the whole booking, the price per day of the rental times the number of nights,
+	// in Currency, the currency of the rental when it was booked.
+	Total    int64
+	Currency string
+	Created  time.Time
+	Updated  time.Time
+}
+
+func IsBookingStatus(status string) bool {
+	_, ok := bookingTransitions[status]
+	return ok
+}
+
+// CanTransition reports whether a booking with the status from can change to the status to.
+func CanTransition(from, to string) bool {
+	for _, status := range bookingTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
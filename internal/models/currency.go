@@ -0,0 +1,25 @@
+package models
+
+import "math"
+
+// DefaultCurrency is the currency rentals are priced in when none is given.
+const DefaultCurrency = "USD"
+
+// IsCurrencyCode reports whether the code looks like an ISO 4217 currency code, three upper case letters.
+func IsCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// ConvertAmount converts an amount of cents with the exchange rate, rounding half up. The rentals filter
+// converts prices with the same float8 operations in SQL, so filters, sorting and responses always agree.
+func ConvertAmount(amount int64, rate float64) int64 {
+	return int64(math.Floor(float64(amount)*rate + 0.5))
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Cursor points right after a rental of a search, so the next page continues from it regardless of
+// rentals being added or removed in between. Keys holds the values of the sort fields of that rental.
+type Cursor struct {
+	Keys   []string `json:"k"`
+	Id     int      `json:"id"`
+	Filter string   `json:"f"`
+}
+
+// NewCursor creates the cursor pointing after the last rental of a search with the params.
+func NewCursor(params GetRentalsParams, last Rental) *Cursor {
+	keys := make([]string, len(params.Sort))
+	for i, field := range params.Sort {
+		keys[i] = last.SortKey(field.Field)
+	}
+
+	return &Cursor{Keys: keys, Id: last.Id, Filter: params.Fingerprint()}
+}
+
+// Encode returns the opaque representation of the cursor handed out to clients.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
+// Fingerprint identifies the filters and sort order of the params, ignoring pagination,
+// so a cursor can only continue the search it was issued for.
+func (p GetRentalsParams) Fingerprint() string {
+	p.Limit, p.Offset, p.After = 0, 0, nil
+	data, _ := json.Marshal(p)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// SortKey formats the value the rental is sorted by for the sort field, in a form the database can parse back exactly.
+func (r Rental) SortKey(field string) string {
+	switch field {
+	case SortFieldPrice:
+		return strconv.FormatInt(r.Price.PerDay, 10)
+	case SortFieldYear:
+		return strconv.Itoa(r.Year)
+	case SortFieldLength:
+		return strconv.FormatFloat(float64(r.Length), 'f', -1, 32)
+	case SortFieldSleeps:
+		return strconv.Itoa(r.Sleeps)
+	case SortFieldName:
+		return r.Name
+	case SortFieldCreated:
+		return r.Created.Format(time.RFC3339Nano)
+	case SortFieldDistance:
+		if r.Distance != nil {
+			return strconv.FormatFloat(*r.Distance, 'g', -1, 64)
+		}
+	case SortFieldRelevance:
+		if r.Relevance != nil {
+			return strconv.FormatFloat(*r.Relevance, 'g', -1, 64)
+		}
+	}
+
+	return ""
+}
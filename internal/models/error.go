@@ -1,21 +1,68 @@
 package models
 
 import (
+	"errors"
 	"fmt"
 )
 
+// The codes are sent to the clients along with the errors, so they can tell the errors apart without parsing
+// their messages. Unlike the messages, they must not change once released.
 const (
-	InternalErrorCode = "InternalError"
-	NotFoundErrorCode = "NotFoundError"
+	InternalErrorCode     = "internal_error"
+	NotFoundErrorCode     = "not_found"
+	BadRequestErrorCode   = "invalid_request"
+	ConflictErrorCode     = "conflict"
+	UnauthorizedErrorCode = "unauthorized"
+	UnavailableErrorCode  = "unavailable"
+	TimeoutErrorCode      = "timeout"
+
+	// MethodNotAllowedCode is returned for the methods a path of the API does not support.
+	MethodNotAllowedCode = "method_not_allowed"
+	// RequestCanceledCode is logged for the requests the clients gave up on before they were served.
+	RequestCanceledCode = "request_canceled"
+
+	RentalNotFoundCode        = "rental_not_found"
+	UserNotFoundCode          = "user_not_found"
+	BookingNotFoundCode       = "booking_not_found"
+	ExchangeRatesNotFoundCode = "exchange_rates_not_found"
+
+	// RentalUnavailableCode is returned when a rental is already booked for some of the requested dates.
+	RentalUnavailableCode = "rental_unavailable"
+	// BookingStatusConflictCode is returned when a booking can not change to the requested status.
+	BookingStatusConflictCode = "booking_status_conflict"
+)
+
+// The kinds of the errors, which errors.Is matches any error of the kind against, however it is wrapped:
+//
+//	if errors.Is(err, models.ErrNotFound) { ... }
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalid      = errors.New("invalid")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrUnavailable  = errors.New("unavailable")
+	ErrTimeout      = errors.New("timeout")
+	ErrInternal     = errors.New("internal")
 )
 
+// ServiceError is the base of the errors of every kind. Msg and Code are meant for the clients, while
+// Cause, the error it was returned for, is only logged, as its details are internal.
 type ServiceError struct {
-	Msg  string
-	Code string
+	Msg   string
+	Code  string
+	Cause error
 }
 
 func (e ServiceError) Error() string {
-	return fmt.Sprintf("'%s': '%s'", e.Code, e.Msg)
+	if e.Cause == nil {
+		return e.Msg
+	}
+
+	return fmt.Sprintf("%s: %v", e.Msg, e.Cause)
+}
+
+func (e ServiceError) Unwrap() error {
+	return e.Cause
 }
 
 func NewServiceError(message, code string) ServiceError {
@@ -25,22 +72,141 @@ func NewServiceError(message, code string) ServiceError {
 	}
 }
 
+// InternalError is returned when a request fails for a reason the client can not do anything about.
 type InternalError ServiceError
 
 func (e InternalError) Error() string {
-	return e.Msg
+	return ServiceError(e).Error()
+}
+
+func (e InternalError) Unwrap() error {
+	return e.Cause
+}
+
+func (e InternalError) Is(target error) bool {
+	return target == ErrInternal
 }
 
-func NewInternalError(msg string) InternalError {
-	return InternalError(NewServiceError(msg, InternalErrorCode))
+// NewInternalError returns an InternalError for the cause, which may be nil when there is none.
+func NewInternalError(msg string, cause error) InternalError {
+	return InternalError{Msg: msg, Code: InternalErrorCode, Cause: cause}
 }
 
 type NotFoundError ServiceError
 
 func (e NotFoundError) Error() string {
-	return e.Msg
+	return ServiceError(e).Error()
+}
+
+func (e NotFoundError) Unwrap() error {
+	return e.Cause
+}
+
+func (e NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// NewNotFoundError returns a NotFoundError with the code of the missing resource, like RentalNotFoundCode.
+func NewNotFoundError(code, msg string) NotFoundError {
+	return NotFoundError(NewServiceError(msg, code))
+}
+
+// ConflictError is returned when a request can not be applied to the current state of a resource.
+type ConflictError ServiceError
+
+func (e ConflictError) Error() string {
+	return ServiceError(e).Error()
+}
+
+func (e ConflictError) Unwrap() error {
+	return e.Cause
+}
+
+func (e ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// NewConflictError returns a ConflictError with the code of the conflict, like RentalUnavailableCode.
+func NewConflictError(code, msg string) ConflictError {
+	return ConflictError(NewServiceError(msg, code))
+}
+
+// UnauthorizedError is returned when a request lacks valid credentials for what it asks for.
+type UnauthorizedError ServiceError
+
+func (e UnauthorizedError) Error() string {
+	return ServiceError(e).Error()
 }
 
-func NewNotFoundError(msg string) NotFoundError {
-	return NotFoundError(NewServiceError(msg, NotFoundErrorCode))
+func (e UnauthorizedError) Unwrap() error {
+	return e.Cause
+}
+
+func (e UnauthorizedError) Is(target error) bool {
+	return target == ErrUnauthorized
+}
+
+func NewUnauthorizedError(msg string) UnauthorizedError {
+	return UnauthorizedError(NewServiceError(msg, UnauthorizedErrorCode))
+}
+
+// UnavailableError is returned when a dependency, like the database, can not be reached for now,
+// so the request may succeed when it is retried.
+type UnavailableError ServiceError
+
+func (e UnavailableError) Error() string {
+	return ServiceError(e).Error()
+}
+
+func (e UnavailableError) Unwrap() error {
+	return e.Cause
+}
+
+func (e UnavailableError) Is(target error) bool {
+	return target == ErrUnavailable
+}
+
+func NewUnavailableError(msg string, cause error) UnavailableError {
+	return UnavailableError{Msg: msg, Code: UnavailableErrorCode, Cause: cause}
+}
+
+// TimeoutError is returned when a request, or a dependency it waits for, runs out of time.
+type TimeoutError ServiceError
+
+func (e TimeoutError) Error() string {
+	return ServiceError(e).Error()
+}
+
+func (e TimeoutError) Unwrap() error {
+	return e.Cause
+}
+
+func (e TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+func NewTimeoutError(msg string, cause error) TimeoutError {
+	return TimeoutError{Msg: msg, Code: TimeoutErrorCode, Cause: cause}
+}
+
+// FieldError describes why a single input field was rejected.
+type FieldError struct {
+	Field string
+	Msg   string
+}
+
+type BadRequestError struct {
+	ServiceError
+	Fields []FieldError
+}
+
+func (e BadRequestError) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+func NewBadRequestError(msg string, fields ...FieldError) BadRequestError {
+	return BadRequestError{
+		ServiceError: NewServiceError(msg, BadRequestErrorCode),
+		Fields:       fields,
+	}
 }
@@ -0,0 +1,48 @@
+package models
+
+// PriceHistogramBuckets is the largest number of equally wide buckets the prices of the facets are split into.
+const PriceHistogramBuckets = 10
+
+// RentalFacets summarizes the rentals matching a search, to render the filters narrowing it down further.
+type RentalFacets struct {
+	Total int
+	Types []FacetCount
+	// Makes are grouped regardless of case, named by their most common spelling.
+	Makes []FacetCount
+	// States are named by their ISO 3166-2 like code, the country and state joined by a dash.
+	States    []FacetCount
+	Countries []FacetCount
+	Sleeps    []FacetCount
+	// Price is nil when no rental matches.
+	Price *PriceStats
+}
+
+// FacetCount is the number of matching rentals with the value, facets are ordered by the most common values first.
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// PriceStats describes the distribution of the prices per day of the matching rentals.
+type PriceStats struct {
+	Min    int64
+	Max    int64
+	P25    int64
+	Median int64
+	P75    int64
+	// Histogram splits the prices from Min to Max into equally wide buckets, including empty ones.
+	Histogram []PriceBucket
+}
+
+// PriceBucket counts the rentals priced from Min up to, but excluding, Max.
+type PriceBucket struct {
+	Min   int64
+	Max   int64
+	Count int
+}
+
+// PriceBucketWidth returns the width of the buckets splitting the prices from min to max into at most
+// PriceHistogramBuckets buckets, never less than one cent.
+func PriceBucketWidth(min, max int64) int64 {
+	return (max-min)/PriceHistogramBuckets + 1
+}
@@ -0,0 +1,25 @@
+package models
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// The dependencies the API checks before it is ready to serve.
+const (
+	HealthCheckDatabase   = "database"
+	HealthCheckMigrations = "migrations"
+	HealthCheckShutdown   = "shutdown"
+)
+
+// DependencyHealth tells whether a dependency is up, and when it is down, why.
+type DependencyHealth struct {
+	Status string
+	Detail string
+}
+
+// Health of the API, which is up only when every one of its dependencies is.
+type Health struct {
+	Status       string
+	Dependencies map[string]DependencyHealth
+}
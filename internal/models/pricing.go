@@ -0,0 +1,64 @@
+package models
+
+// PricingRules of a rental on top of its price per day, the zero value of a rule leaves the price unchanged.
+type PricingRules struct {
+	RentalId int
+	// WeekendPerDay replaces the price per day for Friday and Saturday nights, when set.
+	WeekendPerDay int64
+	// Seasons replace the price per day, and the weekend price, for the nights within them.
+	Seasons []SeasonalPrice
+	// WeeklyDiscountPercent is taken off the nightly prices of stays of at least a week.
+	WeeklyDiscountPercent int
+	// MonthlyDiscountPercent is taken off the nightly prices of stays of at least a month, instead of the weekly one.
+	MonthlyDiscountPercent int
+	CleaningFee            int64
+	// ExtraGuestPerDay is charged every night for each guest beyond the number of guests the rental sleeps.
+	ExtraGuestPerDay int64
+	MinNights        int
+	// TaxRate applied to the whole stay, in basis points, by the state the rental is located in.
+	TaxRate int
+}
+
+// SeasonalPrice is the price per day of a rental for the nights within a range of days.
+type SeasonalPrice struct {
+	DateRange
+	PerDay int64
+}
+
+const (
+	// WeeklyNights is the number of nights from which the weekly discount applies.
+	WeeklyNights = 7
+	// MonthlyNights is the number of nights from which the monthly discount applies.
+	MonthlyNights = 28
+)
+
+const (
+	LineItemNightly         = "nightly"
+	LineItemWeekend         = "weekend"
+	LineItemSeasonal        = "seasonal"
+	LineItemWeeklyDiscount  = "weekly_discount"
+	LineItemMonthlyDiscount = "monthly_discount"
+	LineItemExtraGuests     = "extra_guests"
+	LineItemCleaningFee     = "cleaning_fee"
+	LineItemTax             = "tax"
+)
+
+// LineItem is one charge of a quote, Amount is Quantity times UnitAmount and is negative for discounts.
+type LineItem struct {
+	Type        string
+	Description string
+	Quantity    int
+	UnitAmount  int64
+	Amount      int64
+}
+
+// Quote is the price of a stay at a rental, broken down into line items that add up to Total.
+type Quote struct {
+	RentalId int
+	DateRange
+	Guests    int
+	LineItems []LineItem
+	Total     int64
+	// Currency of all amounts of the quote, the native currency of the rental.
+	Currency string
+}
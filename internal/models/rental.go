@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Rental struct {
 	Id              int
 	Name            string
@@ -14,10 +16,18 @@ type Rental struct {
 	Price           Price
 	Location        Location
 	User            User
+	Created         time.Time
+	Updated         time.Time
+	// Distance to the searched point in the requested unit, only set when searching near a point.
+	Distance *float64
+	// Relevance of the rental to the full-text query, only set when searching with a query.
+	Relevance *float64
 }
 
 type Price struct {
 	PerDay int64
+	// Currency is the ISO 4217 code of the currency PerDay is in.
+	Currency string
 }
 
 type Location struct {
@@ -35,12 +45,169 @@ type User struct {
 	LastName  string
 }
 
+// RentalPatch holds the rental fields to update, nil fields keep their current value.
+type RentalPatch struct {
+	UserId          *int
+	Name            *string
+	Description     *string
+	Type            *string
+	Make            *string
+	Model           *string
+	Year            *int
+	Length          *float32
+	Sleeps          *int
+	PrimaryImageUrl *string
+	PricePerDay     *int64
+	Currency        *string
+	City            *string
+	State           *string
+	Zip             *string
+	Country         *string
+	Lat             *float64
+	Lng             *float64
+}
+
+// Apply sets the fields of the patch on the rental.
+func (p RentalPatch) Apply(rental *Rental) {
+	setIfPresent(&rental.User.Id, p.UserId)
+	setIfPresent(&rental.Name, p.Name)
+	setIfPresent(&rental.Description, p.Description)
+	setIfPresent(&rental.Type, p.Type)
+	setIfPresent(&rental.Make, p.Make)
+	setIfPresent(&rental.Model, p.Model)
+	setIfPresent(&rental.Year, p.Year)
+	setIfPresent(&rental.Length, p.Length)
+	setIfPresent(&rental.Sleeps, p.Sleeps)
+	setIfPresent(&rental.PrimaryImageUrl, p.PrimaryImageUrl)
+	setIfPresent(&rental.Price.PerDay, p.PricePerDay)
+	setIfPresent(&rental.Price.Currency, p.Currency)
+	setIfPresent(&rental.Location.City, p.City)
+	setIfPresent(&rental.Location.State, p.State)
+	setIfPresent(&rental.Location.Zip, p.Zip)
+	setIfPresent(&rental.Location.Country, p.Country)
+	setIfPresent(&rental.Location.Lat, p.Lat)
+	setIfPresent(&rental.Location.Lng, p.Lng)
+}
+
+func setIfPresent[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
 type GetRentalsParams struct {
+	// UserId only returns the rentals owned by the user, when set.
+	UserId   int
 	PriceMin int64
 	PriceMax int64
 	Limit    int
 	Offset   int
 	Ids      []int
 	Near     []float64
-	Sort     string
+	Radius   float64
+	Unit     string
+	BBox     *BoundingBox
+	Polygons []Polygon
+	Sort     []SortField
+	// Currency converts the prices, and the price filters and sort, into the currency when set.
+	// Without it every rental keeps the native currency it is priced in.
+	Currency string
+	// ExchangeRates holds the value of one unit of every convertible currency in Currency. It is
+	// looked up by the service and is not part of the search a cursor is issued for.
+	ExchangeRates map[string]float64 `json:"-"`
+	// Query only returns rentals whose name, description, make or model match the full-text search, when set.
+	Query string
+	// Types, Make, Model and the year, sleeps and length bounds filter by the vehicle, when set.
+	// A rental matches any of the Types, and Make and Model regardless of case.
+	Types     []string
+	Make      string
+	Model     string
+	YearMin   int
+	YearMax   int
+	SleepsMin int
+	LengthMin float64
+	LengthMax float64
+	// Available only returns rentals that are neither booked nor blocked on any day of the range, when set.
+	Available *DateRange
+	// After continues a search right after the rental the cursor points to, instead of using Offset.
+	After *Cursor
+}
+
+const (
+	// DefaultLimit is the page size used when no limit is requested.
+	DefaultLimit = 20
+	// MaxLimit is the largest page size that can be requested.
+	MaxLimit = 100
+)
+
+// RentalsPage is one page of a rentals search together with the number of rentals matching the search.
+type RentalsPage struct {
+	Rentals []Rental
+	Total   int
+	Limit   int
+	Offset  int
+	// NextCursor continues the search after this page, nil on the last page.
+	NextCursor *Cursor
+}
+
+// BoundingBox is a map viewport. MinLng is greater than MaxLng when the box crosses the antimeridian.
+type BoundingBox struct {
+	MinLng float64
+	MinLat float64
+	MaxLng float64
+	MaxLat float64
+}
+
+// Position is a [lng, lat] pair, in GeoJSON order.
+type Position [2]float64
+
+// Polygon is a list of closed linear rings, the first one is the exterior ring and the others are holes.
+type Polygon [][]Position
+
+const (
+	DistanceUnitMiles      = "mi"
+	DistanceUnitKilometers = "km"
+
+	// DefaultRadius is the radius used around the near point when no radius is requested, in the requested unit.
+	DefaultRadius = 100
+)
+
+const (
+	SortFieldPrice    = "price"
+	SortFieldYear     = "year"
+	SortFieldLength   = "length"
+	SortFieldSleeps   = "sleeps"
+	SortFieldName     = "name"
+	SortFieldCreated  = "created"
+	SortFieldDistance = "distance"
+	// SortFieldRelevance orders by the relevance to the full-text query, the best matches first.
+	SortFieldRelevance = "relevance"
+)
+
+var sortFields = map[string]bool{
+	SortFieldPrice:    true,
+	SortFieldYear:     true,
+	SortFieldLength:   true,
+	SortFieldSleeps:   true,
+	SortFieldName:     true,
+	SortFieldCreated:  true,
+	SortFieldDistance: true,
+	// Relevance is descending unless Desc is set, see SortField.Descending.
+	SortFieldRelevance: true,
+}
+
+// SortField orders rentals by one of the public field names, ascending unless Desc is set.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// Descending tells whether the rentals are sorted by the field in descending order. Desc reverses the
+// default order of the field, which is descending for relevance and ascending for every other field.
+func (f SortField) Descending() bool {
+	return f.Desc != (f.Field == SortFieldRelevance)
+}
+
+func IsSortField(field string) bool {
+	return sortFields[field]
 }
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+// exclusionViolation is the Postgres error code returned when a booking overlaps another booking of the same
+// rental, which the exclusion constraint of the bookings table prevents even for concurrent requests.
+const exclusionViolation = "23P01"
+
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOFILE -package=$GOPACKAGE
+type Bookings interface {
+	GetBooking(ctx context.Context, id int) (*models.Booking, error)
+	CreateBooking(ctx context.Context, booking models.Booking) (int, error)
+	UpdateBookingStatus(ctx context.Context, id int, from, to string) error
+}
+
+type BookingsImpl struct {
+	db *sql.DB
+}
+
+func NewBookingsRepo(db *sql.DB) Bookings {
+	return &BookingsImpl{db: db}
+}
+
+func (r *BookingsImpl) GetBooking(ctx context.Context, id int) (*models.Booking, error) {
+	query := `
+		SELECT
+			id,
+			rental_id,
+			user_id,
+			lower(dates),
+			upper(dates),
+			status,
+			total,
+			currency,
+			created,
+			updated
+		FROM bookings
+		WHERE id = $1`
+
+	var booking models.Booking
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&booking.Id,
+		&booking.RentalId,
+		&booking.UserId,
+		&booking.Start,
+		&booking.End,
+		&booking.Status,
+		&booking.Total,
+		&booking.Currency,
+		&booking.Created,
+		&booking.Updated,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NewNotFoundError(models.BookingNotFoundCode, fmt.Sprintf("booking with id %d not found", id))
+		}
+
+		return nil, dbError("failed to get booking", err)
+	}
+
+	booking.Start, booking.End = booking.Start.UTC(), booking.End.UTC()
+	booking.Created, booking.Updated = booking.Created.UTC(), booking.Updated.UTC()
+	return &booking, nil
+}
+
+// CreateBooking inserts the booking unless the rental is blocked or already booked on any of its days, in which
+// case a ConflictError is returned. Overlapping bookings are rejected by the exclusion constraint of the table.
+func (r *BookingsImpl) CreateBooking(ctx context.Context, booking models.Booking) (int, error) {
+	query := `
+		INSERT INTO bookings (
+			rental_id,
+			user_id,
+			dates,
+			status,
+			total,
+			currency,
+			created,
+			updated
+		)
+		SELECT $1::integer, $2::integer, daterange($3::date, $4::date), $5::text, $6::bigint, $7::text, now(), now()
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM blocked_dates AS b
+			WHERE b.rental_id = $1::integer AND b.dates && daterange($3::date, $4::date)
+		)
+		RETURNING id`
+
+	var id int
+	err := r.db.QueryRowContext(ctx, query,
+		booking.RentalId,
+		booking.UserId,
+		booking.Start.Format(models.DateLayout),
+		booking.End.Format(models.DateLayout),
+		booking.Status,
+		booking.Total,
+		booking.Currency,
+	).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.Is(err, sql.ErrNoRows) || errors.As(err, &pqErr) && pqErr.Code == exclusionViolation {
+			return 0, models.NewConflictError(models.RentalUnavailableCode, fmt.Sprintf("rental with id %d is not available from %s to %s",
+				booking.RentalId, booking.Start.Format(models.DateLayout), booking.End.Format(models.DateLayout)))
+		}
+
+		return 0, dbError("failed to create booking", err)
+	}
+
+	return id, nil
+}
+
+// UpdateBookingStatus changes the status of the booking from the status it was read with, so that a concurrent
+// change in between is reported as a ConflictError instead of being overwritten.
+func (r *BookingsImpl) UpdateBookingStatus(ctx context.Context, id int, from, to string) error {
+	query := `
+		UPDATE bookings
+		SET status = $3, updated = now()
+		WHERE id = $1 AND status = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, from, to)
+	if err != nil {
+		return dbError("failed to update booking", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return dbError("failed to get affected bookings", err)
+	}
+
+	if affected == 0 {
+		return models.NewConflictError(models.BookingStatusConflictCode, fmt.Sprintf("booking with id %d is no longer %s", id, from))
+	}
+
+	return nil
+}
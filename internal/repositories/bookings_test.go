@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+func bookingOf(rentalId int, start, end time.Time) models.Booking {
+	return models.Booking{
+		RentalId:  rentalId,
+		UserId:    5,
+		DateRange: models.DateRange{Start: start, End: end},
+		Status:    models.BookingStatusPending,
+		Total:     10000,
+		Currency:  "USD",
+	}
+}
+
+func deleteBooking(id int) {
+	_, _ = database.Exec("DELETE FROM bookings WHERE id = $1", id)
+}
+
+func TestBookings_GetBooking(t *testing.T) {
+	testCases := []struct {
+		name            string
+		id              int
+		expectedBooking *models.Booking
+		expectedError   error
+	}{
+		{
+			name: "Get existing booking",
+			id:   1,
+			expectedBooking: &models.Booking{
+				Id:       1,
+				RentalId: 1,
+				UserId:   5,
+				DateRange: models.DateRange{
+					Start: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC),
+					End:   time.Date(2030, 7, 8, 0, 0, 0, 0, time.UTC),
+				},
+				Status:   models.BookingStatusConfirmed,
+				Total:    118300,
+				Currency: "USD",
+			},
+			expectedError: nil,
+		},
+		{
+			name:            "Get non-existing booking",
+			id:              404,
+			expectedBooking: nil,
+			expectedError:   models.NewNotFoundError(models.BookingNotFoundCode, "booking with id 404 not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewBookingsRepo(database)
+
+			// When
+			booking, err := repo.GetBooking(ctx, tc.id)
+
+			// Then
+			assert.Equal(t, tc.expectedError, err)
+			if booking != nil {
+				// The seeded bookings are created when the test data is loaded.
+				assert.False(t, booking.Created.IsZero())
+				assert.False(t, booking.Updated.IsZero())
+				booking.Created, booking.Updated = time.Time{}, time.Time{}
+			}
+			assert.Equal(t, tc.expectedBooking, booking)
+		})
+	}
+}
+
+func TestBookings_CreateBooking(t *testing.T) {
+	testCases := []struct {
+		name          string
+		booking       models.Booking
+		expectedError error
+	}{
+		{
+			name:          "Book available dates",
+			booking:       bookingOf(1, time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 8, 4, 0, 0, 0, 0, time.UTC)),
+			expectedError: nil,
+		},
+		{
+			name:          "Check-in on the check-out day of a booking",
+			booking:       bookingOf(1, time.Date(2030, 7, 8, 0, 0, 0, 0, time.UTC), time.Date(2030, 7, 10, 0, 0, 0, 0, time.UTC)),
+			expectedError: nil,
+		},
+		{
+			name:          "Book the dates of a cancelled booking",
+			booking:       bookingOf(2, time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 7, 8, 0, 0, 0, 0, time.UTC)),
+			expectedError: nil,
+		},
+		{
+			name:          "Book dates overlapping a booking",
+			booking:       bookingOf(1, time.Date(2030, 7, 5, 0, 0, 0, 0, time.UTC), time.Date(2030, 7, 10, 0, 0, 0, 0, time.UTC)),
+			expectedError: models.NewConflictError(models.RentalUnavailableCode, "rental with id 1 is not available from 2030-07-05 to 2030-07-10"),
+		},
+		{
+			name:          "Book blocked dates",
+			booking:       bookingOf(3, time.Date(2030, 7, 9, 0, 0, 0, 0, time.UTC), time.Date(2030, 7, 12, 0, 0, 0, 0, time.UTC)),
+			expectedError: models.NewConflictError(models.RentalUnavailableCode, "rental with id 3 is not available from 2030-07-09 to 2030-07-12"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewBookingsRepo(database)
+
+			// When
+			id, err := repo.CreateBooking(ctx, tc.booking)
+			t.Cleanup(func() { deleteBooking(id) })
+
+			// Then
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				created, err := repo.GetBooking(ctx, id)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.booking.DateRange, created.DateRange)
+				assert.Equal(t, tc.booking.Status, created.Status)
+				assert.Equal(t, tc.booking.Total, created.Total)
+			}
+		})
+	}
+}
+
+func TestBookings_CreateBooking_Concurrently(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewBookingsRepo(database)
+	booking := bookingOf(2, time.Date(2030, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 9, 5, 0, 0, 0, 0, time.UTC))
+	const requests = 10
+
+	// When
+	var wg sync.WaitGroup
+	ids := make([]int, requests)
+	errs := make([]error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i], errs[i] = repo.CreateBooking(ctx, booking)
+		}(i)
+	}
+	wg.Wait()
+	t.Cleanup(func() {
+		for _, id := range ids {
+			deleteBooking(id)
+		}
+	})
+
+	// Then
+	created := 0
+	for _, err := range errs {
+		if err == nil {
+			created++
+		} else {
+			assert.Equal(t, models.NewConflictError(models.RentalUnavailableCode, "rental with id 2 is not available from 2030-09-01 to 2030-09-05"), err)
+		}
+	}
+	assert.Equal(t, 1, created)
+}
+
+func TestBookings_UpdateBookingStatus(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewBookingsRepo(database)
+	id, err := repo.CreateBooking(ctx, bookingOf(2, time.Date(2030, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 10, 3, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, err)
+	t.Cleanup(func() { deleteBooking(id) })
+
+	// When
+	err = repo.UpdateBookingStatus(ctx, id, models.BookingStatusPending, models.BookingStatusConfirmed)
+
+	// Then
+	assert.NoError(t, err)
+	confirmed, err := repo.GetBooking(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.BookingStatusConfirmed, confirmed.Status)
+
+	// When the status changed since the booking was read
+	err = repo.UpdateBookingStatus(ctx, id, models.BookingStatusPending, models.BookingStatusCancelled)
+
+	// Then
+	assert.Equal(t, models.NewConflictError(models.BookingStatusConflictCode, fmt.Sprintf("booking with id %d is no longer pending", id)), err)
+}
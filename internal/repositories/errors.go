@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/lib/pq"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+// dbError returns the error of a failed query wrapping its cause, telling apart the queries which ran out of time
+// and the ones the database could not be reached for, which may both succeed when they are retried.
+func dbError(msg string, err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		// query_canceled, which the statement timeout cancels the queries with.
+		case pqErr.Code == "57014":
+			return models.NewTimeoutError(msg, err)
+		// connection_exception, insufficient_resources, and the database shutting down or starting up.
+		case pqErr.Code.Class() == "08", pqErr.Code.Class() == "53",
+			pqErr.Code == "57P01", pqErr.Code == "57P02", pqErr.Code == "57P03":
+			return models.NewUnavailableError(msg, err)
+		}
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return models.NewTimeoutError(msg, err)
+	case errors.Is(err, driver.ErrBadConn), errors.As(err, &netErr):
+		return models.NewUnavailableError(msg, err)
+	default:
+		return models.NewInternalError(msg, err)
+	}
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+func TestDbError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedKind error
+	}{
+		{
+			name:         "Statement timeout",
+			err:          &pq.Error{Code: "57014", Message: "canceling statement due to statement timeout"},
+			expectedKind: models.ErrTimeout,
+		},
+		{
+			name:         "Context deadline exceeded",
+			err:          context.DeadlineExceeded,
+			expectedKind: models.ErrTimeout,
+		},
+		{
+			name:         "Database shutting down",
+			err:          &pq.Error{Code: "57P01", Message: "terminating connection due to administrator command"},
+			expectedKind: models.ErrUnavailable,
+		},
+		{
+			name:         "Too many connections",
+			err:          &pq.Error{Code: "53300", Message: "sorry, too many clients already"},
+			expectedKind: models.ErrUnavailable,
+		},
+		{
+			name:         "Bad connection",
+			err:          driver.ErrBadConn,
+			expectedKind: models.ErrUnavailable,
+		},
+		{
+			name:         "Connection refused",
+			err:          &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			expectedKind: models.ErrUnavailable,
+		},
+		{
+			name:         "Undefined table",
+			err:          &pq.Error{Code: "42P01", Message: "relation \"rentals\" does not exist"},
+			expectedKind: models.ErrInternal,
+		},
+		{
+			name:         "Context canceled",
+			err:          context.Canceled,
+			expectedKind: models.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// When
+			err := dbError("failed to get rental", tc.err)
+
+			// Then
+			assert.ErrorIs(t, err, tc.expectedKind)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, "failed to get rental: "+tc.err.Error(), err.Error())
+		})
+	}
+}
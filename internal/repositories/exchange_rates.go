@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOFILE -package=$GOPACKAGE
+type ExchangeRates interface {
+	// Rates returns the value of one unit of every known currency in the currency, including the currency itself.
+	Rates(ctx context.Context, currency string) (map[string]float64, error)
+}
+
+// exchangeRatesFile is the format of the file read by FileExchangeRates, rates are the units of every
+// currency one unit of the base currency buys.
+type exchangeRatesFile struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// FileExchangeRates serves fixed exchange rates read from a JSON file, meant for local testing.
+type FileExchangeRates struct {
+	rates map[string]float64
+}
+
+func NewFileExchangeRates(path string) (ExchangeRates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exchange rates: %w", err)
+	}
+
+	var file exchangeRatesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rates: %w", err)
+	}
+
+	for currency, rate := range file.Rates {
+		if !models.IsCurrencyCode(currency) || rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate %g for currency '%s'", rate, currency)
+		}
+	}
+	if file.Rates[file.Base] != 1 {
+		return nil, fmt.Errorf("the rate of the base currency '%s' must be 1", file.Base)
+	}
+
+	return &FileExchangeRates{rates: file.Rates}, nil
+}
+
+func (r *FileExchangeRates) Rates(ctx context.Context, currency string) (map[string]float64, error) {
+	target, ok := r.rates[currency]
+	if !ok {
+		return nil, models.NewNotFoundError(models.ExchangeRatesNotFoundCode, fmt.Sprintf("no exchange rates for currency '%s'", currency))
+	}
+
+	rates := make(map[string]float64, len(r.rates))
+	for from, rate := range r.rates {
+		rates[from] = target / rate
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+func TestFileExchangeRates_Rates(t *testing.T) {
+	testCases := []struct {
+		name          string
+		currency      string
+		expectedRates map[string]float64
+		expectedError error
+	}{
+		{
+			name:          "Rates into the base currency",
+			currency:      "USD",
+			expectedRates: map[string]float64{"USD": 1, "CAD": 0.8, "GBP": 1.25, "EUR": 1 / 0.9, "AUD": 0.625},
+		},
+		{
+			name:          "Rates into another currency",
+			currency:      "CAD",
+			expectedRates: map[string]float64{"USD": 1.25, "CAD": 1, "GBP": 1.5625, "EUR": 1.25 / 0.9, "AUD": 0.78125},
+		},
+		{
+			name:          "Rates into an unknown currency",
+			currency:      "XYZ",
+			expectedError: models.NewNotFoundError(models.ExchangeRatesNotFoundCode, "no exchange rates for currency 'XYZ'"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			exchangeRates, err := NewFileExchangeRates("../db/test_data/exchange-rates.json")
+			assert.NoError(t, err)
+
+			// When
+			rates, err := exchangeRates.Rates(context.Background(), tc.currency)
+
+			// Then
+			assert.Equal(t, tc.expectedError, err)
+			assert.Len(t, rates, len(tc.expectedRates))
+			for currency, rate := range tc.expectedRates {
+				assert.InDelta(t, rate, rates[currency], 1e-9, currency)
+			}
+		})
+	}
+}
+
+func TestFileExchangeRates_Invalid(t *testing.T) {
+	testCases := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{
+			name:          "Not JSON",
+			content:       "rates",
+			expectedError: "failed to parse exchange rates: invalid character 'r' looking for beginning of value",
+		},
+		{
+			name:          "Rate not positive",
+			content:       `{"base":"USD","rates":{"USD":1,"EUR":0}}`,
+			expectedError: "invalid exchange rate 0 for currency 'EUR'",
+		},
+		{
+			name:          "Currency not a currency code",
+			content:       `{"base":"USD","rates":{"USD":1,"euro":0.9}}`,
+			expectedError: "invalid exchange rate 0.9 for currency 'euro'",
+		},
+		{
+			name:          "Base currency without a rate of 1",
+			content:       `{"base":"USD","rates":{"EUR":0.9}}`,
+			expectedError: "the rate of the base currency 'USD' must be 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			path := filepath.Join(t.TempDir(), "exchange-rates.json")
+			assert.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			// When
+			exchangeRates, err := NewFileExchangeRates(path)
+
+			// Then
+			assert.Nil(t, exchangeRates)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
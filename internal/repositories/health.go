@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/toshko07/outdoorsy-challenge/internal/db"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOFILE -package=$GOPACKAGE
+type Health interface {
+	Ping(ctx context.Context) error
+	// SchemaVersion returns the version of the latest migration applied to the database.
+	SchemaVersion(ctx context.Context) (int, error)
+}
+
+type HealthImpl struct {
+	db       *sql.DB
+	migrator *db.Migrator
+}
+
+func NewHealthRepo(db *sql.DB, migrator *db.Migrator) Health {
+	return &HealthImpl{db: db, migrator: migrator}
+}
+
+func (r *HealthImpl) Ping(ctx context.Context) error {
+	if err := r.db.PingContext(ctx); err != nil {
+		return dbError("failed to ping database", err)
+	}
+
+	return nil
+}
+
+func (r *HealthImpl) SchemaVersion(ctx context.Context) (int, error) {
+	version, err := r.migrator.Version(ctx)
+	if err != nil {
+		return 0, dbError("failed to get schema version", err)
+	}
+
+	return version, nil
+}
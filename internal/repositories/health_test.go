@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/toshko07/outdoorsy-challenge/internal/db"
+)
+
+func TestHealth(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	migrator, err := db.NewMigrator(database)
+	require.NoError(t, err)
+	repo := NewHealthRepo(database, migrator)
+
+	// When
+	pingErr := repo.Ping(ctx)
+	version, versionErr := repo.SchemaVersion(ctx)
+
+	// Then
+	assert.NoError(t, pingErr)
+	assert.NoError(t, versionErr)
+	assert.Equal(t, migrator.LatestVersion(), version)
+}
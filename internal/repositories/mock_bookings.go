@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: bookings.go
+//
+// Generated by this command:
+//
+//	mockgen -source=bookings.go -destination=mock_bookings.go -package=repositories
+//
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/toshko07/outdoorsy-challenge/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBookings is a mock of Bookings interface.
+type MockBookings struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingsMockRecorder
+}
+
+// MockBookingsMockRecorder is the mock recorder for MockBookings.
+type MockBookingsMockRecorder struct {
+	mock *MockBookings
+}
+
+// NewMockBookings creates a new mock instance.
+func NewMockBookings(ctrl *gomock.Controller) *MockBookings {
+	mock := &MockBookings{ctrl: ctrl}
+	mock.recorder = &MockBookingsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookings) EXPECT() *MockBookingsMockRecorder {
+	return m.recorder
+}
+
+// CreateBooking mocks base method.
+func (m *MockBookings) CreateBooking(ctx context.Context, booking models.Booking) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBooking", ctx, booking)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBooking indicates an expected call of CreateBooking.
+func (mr *MockBookingsMockRecorder) CreateBooking(ctx, booking any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBooking", reflect.TypeOf((*MockBookings)(nil).CreateBooking), ctx, booking)
+}
+
+// GetBooking mocks base method.
+func (m *MockBookings) GetBooking(ctx context.Context, id int) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBooking", ctx, id)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBooking indicates an expected call of GetBooking.
+func (mr *MockBookingsMockRecorder) GetBooking(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBooking", reflect.TypeOf((*MockBookings)(nil).GetBooking), ctx, id)
+}
+
+// UpdateBookingStatus mocks base method.
+func (m *MockBookings) UpdateBookingStatus(ctx context.Context, id int, from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBookingStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBookingStatus indicates an expected call of UpdateBookingStatus.
+func (mr *MockBookingsMockRecorder) UpdateBookingStatus(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBookingStatus", reflect.TypeOf((*MockBookings)(nil).UpdateBookingStatus), ctx, id, from, to)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pricing.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pricing.go -destination=mock_pricing.go -package=repositories
+//
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/toshko07/outdoorsy-challenge/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPricing is a mock of Pricing interface.
+type MockPricing struct {
+	ctrl     *gomock.Controller
+	recorder *MockPricingMockRecorder
+}
+
+// MockPricingMockRecorder is the mock recorder for MockPricing.
+type MockPricingMockRecorder struct {
+	mock *MockPricing
+}
+
+// NewMockPricing creates a new mock instance.
+func NewMockPricing(ctrl *gomock.Controller) *MockPricing {
+	mock := &MockPricing{ctrl: ctrl}
+	mock.recorder = &MockPricingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPricing) EXPECT() *MockPricingMockRecorder {
+	return m.recorder
+}
+
+// GetPricingRules mocks base method.
+func (m *MockPricing) GetPricingRules(ctx context.Context, rentalId int, dates models.DateRange) (*models.PricingRules, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPricingRules", ctx, rentalId, dates)
+	ret0, _ := ret[0].(*models.PricingRules)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPricingRules indicates an expected call of GetPricingRules.
+func (mr *MockPricingMockRecorder) GetPricingRules(ctx, rentalId, dates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPricingRules", reflect.TypeOf((*MockPricing)(nil).GetPricingRules), ctx, rentalId, dates)
+}
@@ -40,6 +40,65 @@ func (m *MockRentals) EXPECT() *MockRentalsMockRecorder {
 	return m.recorder
 }
 
+// CountRentals mocks base method.
+func (m *MockRentals) CountRentals(ctx context.Context, params models.GetRentalsParams) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRentals", ctx, params)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRentals indicates an expected call of CountRentals.
+func (mr *MockRentalsMockRecorder) CountRentals(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRentals", reflect.TypeOf((*MockRentals)(nil).CountRentals), ctx, params)
+}
+
+// CreateRental mocks base method.
+func (m *MockRentals) CreateRental(ctx context.Context, rental models.Rental) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRental", ctx, rental)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRental indicates an expected call of CreateRental.
+func (mr *MockRentalsMockRecorder) CreateRental(ctx, rental any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRental", reflect.TypeOf((*MockRentals)(nil).CreateRental), ctx, rental)
+}
+
+// DeleteRental mocks base method.
+func (m *MockRentals) DeleteRental(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRental", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRental indicates an expected call of DeleteRental.
+func (mr *MockRentalsMockRecorder) DeleteRental(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRental", reflect.TypeOf((*MockRentals)(nil).DeleteRental), ctx, id)
+}
+
+// GetFacets mocks base method.
+func (m *MockRentals) GetFacets(ctx context.Context, params models.GetRentalsParams) (*models.RentalFacets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFacets", ctx, params)
+	ret0, _ := ret[0].(*models.RentalFacets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFacets indicates an expected call of GetFacets.
+func (mr *MockRentalsMockRecorder) GetFacets(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFacets", reflect.TypeOf((*MockRentals)(nil).GetFacets), ctx, params)
+}
+
 // GetRental mocks base method.
 func (m *MockRentals) GetRental(ctx context.Context, id int) (*models.Rental, error) {
 	m.ctrl.T.Helper()
@@ -69,3 +128,32 @@ func (mr *MockRentalsMockRecorder) GetRentals(ctx, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRentals", reflect.TypeOf((*MockRentals)(nil).GetRentals), ctx, params)
 }
+
+// GetUnavailablePeriods mocks base method.
+func (m *MockRentals) GetUnavailablePeriods(ctx context.Context, id int, dates models.DateRange) ([]models.UnavailablePeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnavailablePeriods", ctx, id, dates)
+	ret0, _ := ret[0].([]models.UnavailablePeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnavailablePeriods indicates an expected call of GetUnavailablePeriods.
+func (mr *MockRentalsMockRecorder) GetUnavailablePeriods(ctx, id, dates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnavailablePeriods", reflect.TypeOf((*MockRentals)(nil).GetUnavailablePeriods), ctx, id, dates)
+}
+
+// UpdateRental mocks base method.
+func (m *MockRentals) UpdateRental(ctx context.Context, rental models.Rental) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRental", ctx, rental)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRental indicates an expected call of UpdateRental.
+func (mr *MockRentalsMockRecorder) UpdateRental(ctx, rental any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRental", reflect.TypeOf((*MockRentals)(nil).UpdateRental), ctx, rental)
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOFILE -package=$GOPACKAGE
+type Pricing interface {
+	GetPricingRules(ctx context.Context, rentalId int, dates models.DateRange) (*models.PricingRules, error)
+}
+
+type PricingImpl struct {
+	db *sql.DB
+}
+
+func NewPricingRepo(db *sql.DB) Pricing {
+	return &PricingImpl{db: db}
+}
+
+// GetPricingRules returns the pricing rules of the rental, with the tax rate of its state and the seasons
+// overlapping the dates. A rental without pricing rules is priced at its price per day only.
+func (r *PricingImpl) GetPricingRules(ctx context.Context, rentalId int, dates models.DateRange) (*models.PricingRules, error) {
+	query := `
+		SELECT
+			COALESCE(p.weekend_per_day, 0),
+			COALESCE(p.weekly_discount_percent, 0),
+			COALESCE(p.monthly_discount_percent, 0),
+			COALESCE(p.cleaning_fee, 0),
+			COALESCE(p.extra_guest_per_day, 0),
+			COALESCE(p.min_nights, 1),
+			COALESCE(t.rate_basis_points, 0)
+		FROM rentals AS r
+		LEFT JOIN rental_pricing AS p ON p.rental_id = r.id
+		LEFT JOIN tax_rates AS t ON t.country = r.home_country AND t.state = r.home_state
+		WHERE r.id = $1`
+
+	rules := models.PricingRules{RentalId: rentalId}
+	err := r.db.QueryRowContext(ctx, query, rentalId).Scan(
+		&rules.WeekendPerDay,
+		&rules.WeeklyDiscountPercent,
+		&rules.MonthlyDiscountPercent,
+		&rules.CleaningFee,
+		&rules.ExtraGuestPerDay,
+		&rules.MinNights,
+		&rules.TaxRate,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NewNotFoundError(models.RentalNotFoundCode, fmt.Sprintf("rental with id %d not found", rentalId))
+		}
+
+		return nil, dbError("failed to get pricing rules", err)
+	}
+
+	seasons, err := r.getSeasonalPrices(ctx, rentalId, dates)
+	if err != nil {
+		return nil, err
+	}
+	rules.Seasons = seasons
+
+	return &rules, nil
+}
+
+func (r *PricingImpl) getSeasonalPrices(ctx context.Context, rentalId int, dates models.DateRange) ([]models.SeasonalPrice, error) {
+	query := `
+		SELECT
+			lower(dates),
+			upper(dates),
+			per_day
+		FROM seasonal_prices
+		WHERE rental_id = $1 AND dates && daterange($2::date, $3::date)
+		ORDER BY lower(dates)`
+	rows, err := r.db.QueryContext(ctx, query, rentalId, dates.Start.Format(models.DateLayout), dates.End.Format(models.DateLayout))
+	if err != nil {
+		return nil, dbError("failed to get seasonal prices", err)
+	}
+
+	var seasons []models.SeasonalPrice
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var season models.SeasonalPrice
+		if err := rows.Scan(&season.Start, &season.End, &season.PerDay); err != nil {
+			return nil, dbError("failed to get seasonal price", err)
+		}
+
+		season.Start, season.End = season.Start.UTC(), season.End.UTC()
+		seasons = append(seasons, season)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("failed to get seasonal prices", err)
+	}
+
+	return seasons, nil
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+func TestPricing_GetPricingRules(t *testing.T) {
+	winter := models.DateRange{Start: time.Date(2030, 12, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)}
+	summer := models.DateRange{Start: time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 8, 8, 0, 0, 0, 0, time.UTC)}
+
+	testCases := []struct {
+		name          string
+		id            int
+		dates         models.DateRange
+		expectedRules *models.PricingRules
+		expectedError error
+	}{
+		{
+			name:  "Rules with a season overlapping the dates",
+			id:    1,
+			dates: winter,
+			expectedRules: &models.PricingRules{
+				RentalId:      1,
+				WeekendPerDay: 19900,
+				Seasons: []models.SeasonalPrice{
+					{
+						DateRange: models.DateRange{Start: time.Date(2030, 12, 20, 0, 0, 0, 0, time.UTC), End: time.Date(2031, 1, 3, 0, 0, 0, 0, time.UTC)},
+						PerDay:    24900,
+					},
+				},
+				WeeklyDiscountPercent:  10,
+				MonthlyDiscountPercent: 20,
+				CleaningFee:            7500,
+				ExtraGuestPerDay:       1500,
+				MinNights:              2,
+				TaxRate:                725,
+			},
+			expectedError: nil,
+		},
+		{
+			name:  "Rules without a season overlapping the dates",
+			id:    1,
+			dates: summer,
+			expectedRules: &models.PricingRules{
+				RentalId:               1,
+				WeekendPerDay:          19900,
+				WeeklyDiscountPercent:  10,
+				MonthlyDiscountPercent: 20,
+				CleaningFee:            7500,
+				ExtraGuestPerDay:       1500,
+				MinNights:              2,
+				TaxRate:                725,
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "Rental without pricing rules in a state without tax",
+			id:            2,
+			dates:         summer,
+			expectedRules: &models.PricingRules{RentalId: 2, MinNights: 1},
+			expectedError: nil,
+		},
+		{
+			name:          "Non-existing rental",
+			id:            404,
+			dates:         summer,
+			expectedRules: nil,
+			expectedError: models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 404 not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewPricingRepo(database)
+
+			// When
+			rules, err := repo.GetPricingRules(ctx, tc.id, tc.dates)
+
+			// Then
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedRules, rules)
+		})
+	}
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/labstack/gommon/log"
+	"github.com/toshko07/outdoorsy-challenge/internal/metrics"
 	"github.com/toshko07/outdoorsy-challenge/internal/models"
 )
 
@@ -12,17 +17,35 @@ import (
 type Rentals interface {
 	GetRental(ctx context.Context, id int) (*models.Rental, error)
 	GetRentals(ctx context.Context, params models.GetRentalsParams) ([]models.Rental, error)
+	CountRentals(ctx context.Context, params models.GetRentalsParams) (int, error)
+	GetFacets(ctx context.Context, params models.GetRentalsParams) (*models.RentalFacets, error)
+	CreateRental(ctx context.Context, rental models.Rental) (int, error)
+	UpdateRental(ctx context.Context, rental models.Rental) error
+	DeleteRental(ctx context.Context, id int) error
+	GetUnavailablePeriods(ctx context.Context, id int, dates models.DateRange) ([]models.UnavailablePeriod, error)
 }
 
 type RentalsImpl struct {
 	db *sql.DB
+
+	geographyMu sync.Mutex
+	// geography caches whether the rentals table has the PostGIS geog column, nil until it was checked.
+	// A missing column is checked for again once geographyRecheck passed since geographyCheckedAt.
+	geography          *bool
+	geographyCheckedAt time.Time
 }
 
+// geographyRecheck is how long the searches keep falling back to lat and lng before checking again
+// for the geog column, which a migration can add while the server runs.
+const geographyRecheck = time.Minute
+
 func NewRentalsRepo(db *sql.DB) Rentals {
-	return &RentalsImpl{db}
+	return &RentalsImpl{db: db}
 }
 
 func (r *RentalsImpl) GetRental(ctx context.Context, id int) (*models.Rental, error) {
+	defer metrics.ObserveQuery("get_rental")()
+
 	query := `
 		SELECT 
 			r.id,
@@ -34,6 +57,7 @@ func (r *RentalsImpl) GetRental(ctx context.Context, id int) (*models.Rental, er
 			description,
 			sleeps,
 			price_per_day,
+			currency,
 			home_city,
 			home_state,
 			home_zip,
@@ -44,7 +68,9 @@ func (r *RentalsImpl) GetRental(ctx context.Context, id int) (*models.Rental, er
 			vehicle_length,
 			lat,
 			lng,
-			primary_image_url
+			primary_image_url,
+			created,
+			updated
 		FROM rentals AS r
 		JOIN users ON r.user_id = users.id
 		WHERE r.id = $1`
@@ -54,6 +80,7 @@ func (r *RentalsImpl) GetRental(ctx context.Context, id int) (*models.Rental, er
 	var user models.User
 	var price models.Price
 	var location models.Location
+	var created, updated sql.NullTime
 
 	err := row.Scan(
 		&rental.Id,
@@ -65,6 +92,7 @@ func (r *RentalsImpl) GetRental(ctx context.Context, id int) (*models.Rental, er
 		&rental.Description,
 		&rental.Sleeps,
 		&price.PerDay,
+		&price.Currency,
 		&location.City,
 		&location.State,
 		&location.Zip,
@@ -76,26 +104,47 @@ func (r *RentalsImpl) GetRental(ctx context.Context, id int) (*models.Rental, er
 		&location.Lat,
 		&location.Lng,
 		&rental.PrimaryImageUrl,
+		&created,
+		&updated,
 	)
 
 	rental.User = user
 	rental.Price = price
 	rental.Location = location
+	rental.Created = created.Time.UTC()
+	rental.Updated = updated.Time.UTC()
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, models.NewNotFoundError(fmt.Sprintf("rental with id %d not found", id))
+			return nil, models.NewNotFoundError(models.RentalNotFoundCode, fmt.Sprintf("rental with id %d not found", id))
 		}
 
-		return nil, models.NewInternalError(fmt.Sprintf("failed to get rental: %v", err))
+		return nil, dbError("failed to get rental", err)
 	}
 
 	return &rental, nil
 }
 
 func (r *RentalsImpl) GetRentals(ctx context.Context, params models.GetRentalsParams) ([]models.Rental, error) {
-	query := `
-		SELECT 
+	defer metrics.ObserveQuery("get_rentals")()
+
+	filter, err := r.filter(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.After != nil {
+		if err := filter.after(params.Sort, params.After); err != nil {
+			return nil, err
+		}
+	}
+
+	orderBy, err := filter.orderBy(params.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := `
 			r.id,
 			user_id,
 			users.first_name,
@@ -105,6 +154,7 @@ func (r *RentalsImpl) GetRentals(ctx context.Context, params models.GetRentalsPa
 			description,
 			sleeps,
 			price_per_day as price,
+			currency,
 			home_city as city,
 			home_state as state,
 			home_zip as zip,
@@ -115,41 +165,20 @@ func (r *RentalsImpl) GetRentals(ctx context.Context, params models.GetRentalsPa
 			vehicle_length as length,
 			lat,
 			lng,
-			primary_image_url as image_url
-		FROM rentals AS r
-		JOIN users ON r.user_id = users.id
-		WHERE 1 = 1`
-
-	var args []interface{}
-
-	if len(params.Ids) > 0 {
-		query += fmt.Sprintf(" AND r.id IN (%s)", createPlaceholders(len(params.Ids)))
-		for _, id := range params.Ids {
-			args = append(args, id)
-		}
-	}
-
-	if params.PriceMin > 0 {
-		query += fmt.Sprintf(" AND price_per_day >= $%d", len(args)+1)
-		args = append(args, params.PriceMin)
-	}
-
-	if params.PriceMax > 0 {
-		query += fmt.Sprintf(" AND price_per_day <= $%d", len(args)+1)
-		args = append(args, params.PriceMax)
+			primary_image_url as image_url,
+			created,
+			updated`
+	if filter.distance != "" {
+		columns += fmt.Sprintf(`,
+			%s as distance`, filter.distance)
 	}
-
-	if len(params.Near) > 0 {
-		// This code block calculates the distance between two geographical coordinates using the Haversine formula.
-		// It filters results within a 100 miles radius.
-		// The distance calculation is based on the latitude and longitude values of the coordinates.
-		query += fmt.Sprintf(" AND (3959 * acos(cos(radians($%d)) * cos(radians(lat)) * cos(radians(lng) - radians($%d)) + sin(radians($%d)) * sin(radians(lat)))) <= 100", len(args)+1, len(args)+2, len(args)+1)
-		args = append(args, params.Near[0], params.Near[1])
+	if filter.relevance != "" {
+		columns += fmt.Sprintf(`,
+			%s as relevance`, filter.relevance)
 	}
 
-	if params.Sort != "" {
-		query += fmt.Sprintf(" ORDER BY %s", params.Sort)
-	}
+	query := fmt.Sprintf(`
+		SELECT %s%s%s`, columns, filter.fromClause(), orderBy)
 
 	if params.Offset > 0 {
 		query += fmt.Sprintf(" OFFSET %d", params.Offset)
@@ -159,9 +188,9 @@ func (r *RentalsImpl) GetRentals(ctx context.Context, params models.GetRentalsPa
 		query += fmt.Sprintf(" LIMIT %d", params.Limit)
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.db.QueryContext(ctx, query, filter.args...)
 	if err != nil {
-		return nil, models.NewInternalError(fmt.Sprintf("failed to get rentals: %v", err))
+		return nil, dbError("failed to get rentals", err)
 	}
 
 	var rentals []models.Rental
@@ -173,8 +202,10 @@ func (r *RentalsImpl) GetRentals(ctx context.Context, params models.GetRentalsPa
 		var user models.User
 		var price models.Price
 		var location models.Location
+		var created, updated sql.NullTime
+		var distance, relevance float64
 
-		err := rows.Scan(
+		dest := []interface{}{
 			&rental.Id,
 			&user.Id,
 			&user.FirstName,
@@ -184,6 +215,7 @@ func (r *RentalsImpl) GetRentals(ctx context.Context, params models.GetRentalsPa
 			&rental.Description,
 			&rental.Sleeps,
 			&price.PerDay,
+			&price.Currency,
 			&location.City,
 			&location.State,
 			&location.Zip,
@@ -195,27 +227,360 @@ func (r *RentalsImpl) GetRentals(ctx context.Context, params models.GetRentalsPa
 			&location.Lat,
 			&location.Lng,
 			&rental.PrimaryImageUrl,
-		)
+			&created,
+			&updated,
+		}
+		if filter.distance != "" {
+			dest = append(dest, &distance)
+		}
+		if filter.relevance != "" {
+			dest = append(dest, &relevance)
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, dbError("failed to get rental", err)
+		}
 
 		rental.User = user
 		rental.Price = price
 		rental.Location = location
-
-		if err != nil {
-			return nil, models.NewInternalError(fmt.Sprintf("failed to get rental: %v", err))
+		rental.Created = created.Time.UTC()
+		rental.Updated = updated.Time.UTC()
+		if filter.distance != "" {
+			rental.Distance = &distance
+		}
+		if filter.relevance != "" {
+			rental.Relevance = &relevance
 		}
 
 		rentals = append(rentals, rental)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, dbError("failed to get rentals", err)
+	}
+
 	return rentals, nil
 }
 
-func createPlaceholders(count int) string {
-	placeholders := ""
-	for i := 1; i <= count; i++ {
-		placeholders += fmt.Sprintf("$%d,", i)
+// CountRentals counts all rentals matching the filters of the params, ignoring sorting and pagination.
+func (r *RentalsImpl) CountRentals(ctx context.Context, params models.GetRentalsParams) (int, error) {
+	defer metrics.ObserveQuery("count_rentals")()
+
+	filter, err := r.filter(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT count(*)%s`, filter.fromClause())
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, filter.args...).Scan(&count); err != nil {
+		return 0, dbError("failed to count rentals", err)
+	}
+
+	return count, nil
+}
+
+// CreateRental inserts the rental owned by rental.User and returns its id, setting both created and updated to now.
+func (r *RentalsImpl) CreateRental(ctx context.Context, rental models.Rental) (int, error) {
+	defer metrics.ObserveQuery("create_rental")()
+
+	// The geog column, when there is one, is set from lat and lng by a trigger.
+	query := `
+		INSERT INTO rentals (
+			user_id,
+			name,
+			type,
+			description,
+			sleeps,
+			price_per_day,
+			home_city,
+			home_state,
+			home_zip,
+			home_country,
+			vehicle_make,
+			vehicle_model,
+			vehicle_year,
+			vehicle_length,
+			lat,
+			lng,
+			primary_image_url,
+			currency,
+			created,
+			updated
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, now(), now())
+		RETURNING id`
+
+	var id int
+	err := r.db.QueryRowContext(ctx, query, rentalValues(rental)...).Scan(&id)
+	if err != nil {
+		return 0, dbError("failed to create rental", err)
+	}
+
+	return id, nil
+}
+
+// UpdateRental replaces the editable fields of the rental with rental.Id and sets updated to now.
+func (r *RentalsImpl) UpdateRental(ctx context.Context, rental models.Rental) error {
+	defer metrics.ObserveQuery("update_rental")()
+
+	// The geog column, when there is one, follows lat and lng through a trigger.
+	query := `
+		UPDATE rentals
+		SET
+			user_id = $1,
+			name = $2,
+			type = $3,
+			description = $4,
+			sleeps = $5,
+			price_per_day = $6,
+			home_city = $7,
+			home_state = $8,
+			home_zip = $9,
+			home_country = $10,
+			vehicle_make = $11,
+			vehicle_model = $12,
+			vehicle_year = $13,
+			vehicle_length = $14,
+			lat = $15,
+			lng = $16,
+			primary_image_url = $17,
+			currency = $18,
+			updated = now()
+		WHERE id = $19`
+
+	result, err := r.db.ExecContext(ctx, query, append(rentalValues(rental), rental.Id)...)
+	if err != nil {
+		return dbError("failed to update rental", err)
+	}
+
+	return rentalAffected(result, rental.Id)
+}
+
+func (r *RentalsImpl) DeleteRental(ctx context.Context, id int) error {
+	defer metrics.ObserveQuery("delete_rental")()
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM rentals WHERE id = $1", id)
+	if err != nil {
+		return dbError("failed to delete rental", err)
+	}
+
+	return rentalAffected(result, id)
+}
+
+// GetUnavailablePeriods returns the bookings and blocked dates of the rental overlapping the range,
+// clipped to the range and ordered by their start.
+func (r *RentalsImpl) GetUnavailablePeriods(ctx context.Context, id int, dates models.DateRange) ([]models.UnavailablePeriod, error) {
+	defer metrics.ObserveQuery("get_unavailable_periods")()
+
+	query := `
+		SELECT
+			lower(u.dates * requested.dates),
+			upper(u.dates * requested.dates),
+			u.reason
+		FROM unavailable_dates AS u
+		CROSS JOIN (SELECT daterange($2::date, $3::date) AS dates) AS requested
+		WHERE u.rental_id = $1 AND u.dates && requested.dates
+		ORDER BY 1, 2`
+	rows, err := r.db.QueryContext(ctx, query, id, dates.Start.Format(models.DateLayout), dates.End.Format(models.DateLayout))
+	if err != nil {
+		return nil, dbError("failed to get unavailable periods", err)
+	}
+
+	var periods []models.UnavailablePeriod
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var period models.UnavailablePeriod
+		if err := rows.Scan(&period.Start, &period.End, &period.Reason); err != nil {
+			return nil, dbError("failed to get unavailable period", err)
+		}
+
+		period.Start, period.End = period.Start.UTC(), period.End.UTC()
+		periods = append(periods, period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("failed to get unavailable periods", err)
+	}
+
+	return periods, nil
+}
+
+// rentalValues lists the editable fields of the rental in the column order used by CreateRental and UpdateRental.
+func rentalValues(rental models.Rental) []interface{} {
+	return []interface{}{
+		rental.User.Id,
+		rental.Name,
+		rental.Type,
+		rental.Description,
+		rental.Sleeps,
+		rental.Price.PerDay,
+		rental.Location.City,
+		rental.Location.State,
+		rental.Location.Zip,
+		rental.Location.Country,
+		rental.Make,
+		rental.Model,
+		rental.Year,
+		rental.Length,
+		rental.Location.Lat,
+		rental.Location.Lng,
+		rental.PrimaryImageUrl,
+		rental.Price.Currency,
+	}
+}
+
+// rentalAffected returns a NotFoundError when the statement did not affect the rental with the id.
+func rentalAffected(result sql.Result, id int) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return dbError("failed to get affected rentals", err)
+	}
+
+	if affected == 0 {
+		return models.NewNotFoundError(models.RentalNotFoundCode, fmt.Sprintf("rental with id %d not found", id))
+	}
+
+	return nil
+}
+
+// filter builds the filter of the search params. Listing, counting and the facets all select the
+// rentals through it, so they always agree on which rentals match.
+func (r *RentalsImpl) filter(ctx context.Context, params models.GetRentalsParams) (*rentalsFilter, error) {
+	geography, err := r.hasGeography(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRentalsFilter(params, geography)
+}
+
+// hasGeography reports whether proximity search can use the PostGIS geog column. The column only exists
+// when the PostGIS extension is installed, so without it searches fall back to plain lat and lng.
+func (r *RentalsImpl) hasGeography(ctx context.Context) (bool, error) {
+	r.geographyMu.Lock()
+	defer r.geographyMu.Unlock()
+
+	if r.geography != nil && (*r.geography || time.Since(r.geographyCheckedAt) < geographyRecheck) {
+		return *r.geography, nil
+	}
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = 'rentals' AND column_name = 'geog'
+		)`
+	var geography bool
+	if err := r.db.QueryRowContext(ctx, query).Scan(&geography); err != nil {
+		return false, dbError("failed to check for the geog column", err)
+	}
+
+	if !geography && r.geography == nil {
+		log.Warn("rentals.geog column not found, proximity search falls back to the Haversine formula")
+	}
+	r.geography = &geography
+	r.geographyCheckedAt = time.Now()
+	return geography, nil
+}
+
+// sortExpressions maps the public sort fields to the SQL expressions they order by.
+// Only these expressions are ever interpolated into the ORDER BY clause.
+var sortExpressions = map[string]string{
+	models.SortFieldYear:    "r.vehicle_year",
+	models.SortFieldLength:  "r.vehicle_length",
+	models.SortFieldSleeps:  "r.sleeps",
+	models.SortFieldName:    "r.name",
+	models.SortFieldCreated: "r.created",
+}
+
+// sortExpression returns the SQL expression ordering by the sort field. The price, distance and
+// relevance expressions come from the filter, which converts prices into the requested currency,
+// measures distances to the near point and ranks the matches of the full-text query.
+func (f *rentalsFilter) sortExpression(field string) (string, error) {
+	switch {
+	case field == models.SortFieldPrice:
+		return f.price, nil
+	case field == models.SortFieldDistance && f.distance != "":
+		return f.distance, nil
+	case field == models.SortFieldRelevance && f.relevance != "":
+		return f.relevance, nil
+	}
+
+	expression, ok := sortExpressions[field]
+	if !ok {
+		return "", models.NewBadRequestError(fmt.Sprintf("can not sort rentals by '%s'", field))
+	}
+
+	return expression, nil
+}
+
+// orderBy creates the ORDER BY clause for the sort fields, always ending with r.id so pages are stable.
+func (f *rentalsFilter) orderBy(sort []models.SortField) (string, error) {
+	var keys []string
+	for _, field := range sort {
+		expression, err := f.sortExpression(field.Field)
+		if err != nil {
+			return "", err
+		}
+
+		if field.Descending() {
+			expression += " DESC NULLS LAST"
+		}
+		keys = append(keys, expression)
+	}
+
+	return fmt.Sprintf(" ORDER BY %s", strings.Join(append(keys, "r.id"), ", ")), nil
+}
+
+// after restricts the search to the rentals sorted after the one the cursor points to.
+// When every field is sorted ascending a single row comparison is used, which the database can
+// match against an index. Mixed directions are expanded into one condition per sort field.
+func (f *rentalsFilter) after(sort []models.SortField, cursor *models.Cursor) error {
+	if len(cursor.Keys) != len(sort) {
+		return models.NewBadRequestError("cursor does not match the sort order")
 	}
 
-	return placeholders[:len(placeholders)-1]
+	expressions := make([]string, 0, len(sort)+1)
+	values := make([]string, 0, len(sort)+1)
+	ascending := true
+	for i, field := range sort {
+		expression, err := f.sortExpression(field.Field)
+		if err != nil {
+			return err
+		}
+
+		expressions = append(expressions, expression)
+		values = append(values, f.arg(cursor.Keys[i]))
+		ascending = ascending && !field.Descending()
+	}
+	expressions = append(expressions, "r.id")
+	values = append(values, f.arg(cursor.Id))
+
+	if ascending {
+		f.where(fmt.Sprintf("(%s) > (%s)", strings.Join(expressions, ", "), strings.Join(values, ", ")))
+		return nil
+	}
+
+	alternatives := make([]string, len(expressions))
+	for i := range expressions {
+		operator := ">"
+		if i < len(sort) && sort[i].Descending() {
+			operator = "<"
+		}
+
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, fmt.Sprintf("%s = %s", expressions[j], values[j]))
+		}
+		terms = append(terms, fmt.Sprintf("%s %s %s", expressions[i], operator, values[i]))
+		alternatives[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+	f.where("(" + strings.Join(alternatives, " OR ") + ")")
+	return nil
 }
@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/toshko07/outdoorsy-challenge/internal/metrics"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+// GetFacets summarizes the rentals matching the filters of the params, ignoring sorting and pagination.
+// The facets are read from a single snapshot, so they add up even while rentals change.
+func (r *RentalsImpl) GetFacets(ctx context.Context, params models.GetRentalsParams) (*models.RentalFacets, error) {
+	defer metrics.ObserveQuery("get_facets")()
+
+	filter, err := r.filter(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, dbError("failed to get rental facets", err)
+	}
+	defer tx.Rollback()
+
+	facets := &models.RentalFacets{}
+	if err := getFacetCounts(ctx, tx, filter, facets); err != nil {
+		return nil, err
+	}
+
+	if err := getPriceStats(ctx, tx, filter, facets); err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// getFacetCounts counts the matching rentals per type, make, state, country and sleeps.
+func getFacetCounts(ctx context.Context, tx *sql.Tx, filter *rentalsFilter, facets *models.RentalFacets) error {
+	query := fmt.Sprintf(`
+		WITH matches AS (
+			SELECT r.type, r.vehicle_make, r.home_state, r.home_country, r.sleeps%s
+		)
+		SELECT 'type', type, count(*) FROM matches GROUP BY type
+		UNION ALL
+		SELECT 'make', mode() WITHIN GROUP (ORDER BY vehicle_make), count(*) FROM matches GROUP BY lower(vehicle_make)
+		UNION ALL
+		SELECT 'state', home_country || '-' || home_state, count(*) FROM matches WHERE home_state <> '' GROUP BY home_country, home_state
+		UNION ALL
+		SELECT 'country', home_country, count(*) FROM matches GROUP BY home_country
+		UNION ALL
+		SELECT 'sleeps', sleeps::text, count(*) FROM matches GROUP BY sleeps
+		ORDER BY 1, 3 DESC, 2`, filter.fromClause())
+
+	rows, err := tx.QueryContext(ctx, query, filter.args...)
+	if err != nil {
+		return dbError("failed to get rental facets", err)
+	}
+
+	defer rows.Close()
+
+	facetsByName := map[string]*[]models.FacetCount{
+		"type":    &facets.Types,
+		"make":    &facets.Makes,
+		"state":   &facets.States,
+		"country": &facets.Countries,
+		"sleeps":  &facets.Sleeps,
+	}
+	for rows.Next() {
+		var name string
+		var count models.FacetCount
+		if err := rows.Scan(&name, &count.Value, &count.Count); err != nil {
+			return dbError("failed to get rental facets", err)
+		}
+
+		*facetsByName[name] = append(*facetsByName[name], count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return dbError("failed to get rental facets", err)
+	}
+
+	return nil
+}
+
+// getPriceStats sets the number of matching rentals and the distribution of their prices, in the
+// requested currency like the price filters.
+func getPriceStats(ctx context.Context, tx *sql.Tx, filter *rentalsFilter, facets *models.RentalFacets) error {
+	matches := fmt.Sprintf(`
+		WITH matches AS (
+			SELECT %s AS price%s
+		)`, filter.price, filter.fromClause())
+
+	query := matches + `
+		SELECT
+			count(*),
+			coalesce(min(price), 0),
+			coalesce(max(price), 0),
+			percentile_disc(ARRAY[0.25, 0.5, 0.75]) WITHIN GROUP (ORDER BY price)
+		FROM matches`
+
+	var price models.PriceStats
+	var percentiles pq.Int64Array
+	err := tx.QueryRowContext(ctx, query, filter.args...).Scan(&facets.Total, &price.Min, &price.Max, &percentiles)
+	if err != nil {
+		return dbError("failed to get rental price stats", err)
+	}
+
+	if facets.Total == 0 {
+		return nil
+	}
+	price.P25, price.Median, price.P75 = percentiles[0], percentiles[1], percentiles[2]
+
+	width := models.PriceBucketWidth(price.Min, price.Max)
+	price.Histogram = make([]models.PriceBucket, (price.Max-price.Min)/width+1)
+	for i := range price.Histogram {
+		price.Histogram[i].Min = price.Min + int64(i)*width
+		price.Histogram[i].Max = price.Histogram[i].Min + width
+	}
+
+	// The bucket arguments come after the ones of the filter, which the matches only refer to.
+	query = matches + fmt.Sprintf(`
+		SELECT (price - %s) / %s AS bucket, count(*)
+		FROM matches
+		GROUP BY bucket`, filter.arg(price.Min), filter.arg(width))
+
+	rows, err := tx.QueryContext(ctx, query, filter.args...)
+	if err != nil {
+		return dbError("failed to get rental price histogram", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var bucket int64
+		var count int
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return dbError("failed to get rental price histogram", err)
+		}
+
+		price.Histogram[bucket].Count = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return dbError("failed to get rental price histogram", err)
+	}
+
+	facets.Price = &price
+	return nil
+}
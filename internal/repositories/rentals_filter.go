@@ -0,0 +1,231 @@
+package repositories
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+// earthRadius is the mean radius of the earth in every supported distance unit.
+var earthRadius = map[string]float64{
+	models.DistanceUnitMiles:      3959,
+	models.DistanceUnitKilometers: 6371,
+}
+
+// metersPerUnit converts the supported distance units to the meters PostGIS works with.
+var metersPerUnit = map[string]float64{
+	models.DistanceUnitMiles:      1609.344,
+	models.DistanceUnitKilometers: 1000,
+}
+
+// rentalsFilter holds the WHERE conditions of a rentals search together with their arguments.
+type rentalsFilter struct {
+	conditions []string
+	args       []interface{}
+	// distance is the SQL expression computing the distance to the near point, empty without a near filter.
+	distance string
+	// price is the SQL expression of the price per day in the requested currency, or in the native one without.
+	price string
+	// relevance is the SQL expression ranking the match of the full-text query, empty without a query.
+	relevance string
+}
+
+// newRentalsFilter builds the filter for the search params. With geography set the near filter uses the
+// indexed PostGIS geog column, otherwise it falls back to the Haversine formula over lat and lng.
+func newRentalsFilter(params models.GetRentalsParams, geography bool) (*rentalsFilter, error) {
+	f := &rentalsFilter{price: "r.price_per_day"}
+
+	if params.Currency != "" {
+		// Rentals priced in a currency without an exchange rate can not be converted, so they are left out.
+		currencies := make([]string, 0, len(params.ExchangeRates))
+		for currency := range params.ExchangeRates {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+
+		placeholders := make([]string, len(currencies))
+		cases := make([]string, len(currencies))
+		for i, currency := range currencies {
+			placeholders[i] = f.arg(currency)
+			rate := strconv.FormatFloat(params.ExchangeRates[currency], 'g', -1, 64)
+			cases[i] = fmt.Sprintf("WHEN %s THEN %s::float8", placeholders[i], f.arg(rate))
+		}
+		f.where(fmt.Sprintf("r.currency IN (%s)", strings.Join(placeholders, ",")))
+
+		// The same float8 operations as models.ConvertAmount, so the converted prices match exactly.
+		f.price = fmt.Sprintf("floor(r.price_per_day * (CASE r.currency %s END) + 0.5::float8)::bigint", strings.Join(cases, " "))
+	}
+
+	if params.Query != "" {
+		// Full-text matches are stemmed, so case and word forms do not matter. Makes and models also
+		// match by trigram word similarity, which tolerates typos like "toyta" for "Toyota".
+		q := f.arg(params.Query)
+		query := fmt.Sprintf("websearch_to_tsquery('english', %s)", q)
+		f.where(fmt.Sprintf("(r.search @@ %s OR %s <%% r.vehicle_make OR %s <%% r.vehicle_model)", query, q, q))
+		f.relevance = fmt.Sprintf("(ts_rank(r.search, %s) + greatest(word_similarity(%s, r.vehicle_make), word_similarity(%s, r.vehicle_model)))::float8", query, q, q)
+	}
+
+	if params.UserId > 0 {
+		f.where(fmt.Sprintf("r.user_id = %s", f.arg(params.UserId)))
+	}
+
+	if len(params.Ids) > 0 {
+		placeholders := make([]string, len(params.Ids))
+		for i, id := range params.Ids {
+			placeholders[i] = f.arg(id)
+		}
+		f.where(fmt.Sprintf("r.id IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	if len(params.Types) > 0 {
+		placeholders := make([]string, len(params.Types))
+		for i, rentalType := range params.Types {
+			placeholders[i] = f.arg(rentalType)
+		}
+		f.where(fmt.Sprintf("r.type IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	if params.Make != "" {
+		f.where(fmt.Sprintf("lower(r.vehicle_make) = lower(%s)", f.arg(params.Make)))
+	}
+
+	if params.Model != "" {
+		f.where(fmt.Sprintf("lower(r.vehicle_model) = lower(%s)", f.arg(params.Model)))
+	}
+
+	if params.YearMin > 0 {
+		f.where(fmt.Sprintf("r.vehicle_year >= %s", f.arg(params.YearMin)))
+	}
+
+	if params.YearMax > 0 {
+		f.where(fmt.Sprintf("r.vehicle_year <= %s", f.arg(params.YearMax)))
+	}
+
+	if params.SleepsMin > 0 {
+		f.where(fmt.Sprintf("r.sleeps >= %s", f.arg(params.SleepsMin)))
+	}
+
+	if params.LengthMin > 0 {
+		f.where(fmt.Sprintf("r.vehicle_length >= %s", f.arg(params.LengthMin)))
+	}
+
+	if params.LengthMax > 0 {
+		f.where(fmt.Sprintf("r.vehicle_length <= %s", f.arg(params.LengthMax)))
+	}
+
+	if params.PriceMin > 0 {
+		f.where(fmt.Sprintf("%s >= %s", f.price, f.arg(params.PriceMin)))
+	}
+
+	if params.PriceMax > 0 {
+		f.where(fmt.Sprintf("%s <= %s", f.price, f.arg(params.PriceMax)))
+	}
+
+	if len(params.Near) == 2 {
+		unit := params.Unit
+		if unit == "" {
+			unit = models.DistanceUnitMiles
+		}
+		radius, ok := earthRadius[unit]
+		if !ok {
+			return nil, models.NewBadRequestError(fmt.Sprintf("unknown distance unit '%s'", params.Unit))
+		}
+
+		maxDistance := params.Radius
+		if maxDistance <= 0 {
+			maxDistance = models.DefaultRadius
+		}
+
+		lat, lng := f.arg(params.Near[0]), f.arg(params.Near[1])
+		if geography {
+			// Distances are measured on a sphere, which is fast and matches the Haversine fallback.
+			point := fmt.Sprintf("ST_SetSRID(ST_MakePoint(%s, %s), 4326)::geography", lng, lat)
+			f.distance = fmt.Sprintf("(ST_Distance(r.geog, %s, false) / %g)", point, metersPerUnit[unit])
+			f.where(fmt.Sprintf("ST_DWithin(r.geog, %s, %s, false)", point, f.arg(maxDistance*metersPerUnit[unit])))
+		} else {
+			// The distance between two geographical coordinates is calculated using the Haversine formula.
+			// least() keeps rounding errors from pushing the acos argument above 1 for identical points.
+			f.distance = fmt.Sprintf("(%g * acos(least(1, cos(radians(%s)) * cos(radians(r.lat)) * cos(radians(r.lng) - radians(%s)) + sin(radians(%s)) * sin(radians(r.lat)))))", radius, lat, lng, lat)
+			f.where(fmt.Sprintf("%s <= %s", f.distance, f.arg(maxDistance)))
+		}
+	}
+
+	if params.BBox != nil {
+		box := params.BBox
+		f.where(fmt.Sprintf("r.lat BETWEEN %s AND %s", f.arg(box.MinLat), f.arg(box.MaxLat)))
+		if box.MinLng <= box.MaxLng {
+			f.where(fmt.Sprintf("r.lng BETWEEN %s AND %s", f.arg(box.MinLng), f.arg(box.MaxLng)))
+		} else {
+			// The box crosses the antimeridian, so it covers both ends of the longitude range.
+			f.where(fmt.Sprintf("(r.lng >= %s OR r.lng <= %s)", f.arg(box.MinLng), f.arg(box.MaxLng)))
+		}
+	}
+
+	if params.Available != nil {
+		// unavailable_dates combines the active bookings and the blocked dates of all rentals.
+		f.where(fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM unavailable_dates AS u
+			WHERE u.rental_id = r.id AND u.dates && daterange(%s::date, %s::date)
+		)`, f.arg(params.Available.Start.Format(models.DateLayout)), f.arg(params.Available.End.Format(models.DateLayout))))
+	}
+
+	if len(params.Polygons) > 0 {
+		// The database of the deployment lacks PostGIS, so the search fails until it is installed and migrated.
+		if !geography {
+			return nil, models.NewUnavailableError("polygon search requires the PostGIS geog column", nil)
+		}
+
+		// Geography edges take the shortest path between two positions, so polygons crossing
+		// the antimeridian cover the expected area without splitting them.
+		f.where(fmt.Sprintf("ST_Covers(ST_GeogFromText(%s), r.geog)", f.arg(multiPolygonWKT(params.Polygons))))
+	}
+
+	return f, nil
+}
+
+// multiPolygonWKT formats the polygons as a WKT MULTIPOLYGON.
+func multiPolygonWKT(polygons []models.Polygon) string {
+	formatted := make([]string, len(polygons))
+	for i, polygon := range polygons {
+		rings := make([]string, len(polygon))
+		for j, ring := range polygon {
+			positions := make([]string, len(ring))
+			for k, position := range ring {
+				positions[k] = strconv.FormatFloat(position[0], 'f', -1, 64) + " " + strconv.FormatFloat(position[1], 'f', -1, 64)
+			}
+			rings[j] = "(" + strings.Join(positions, ",") + ")"
+		}
+		formatted[i] = "(" + strings.Join(rings, ",") + ")"
+	}
+
+	return "MULTIPOLYGON(" + strings.Join(formatted, ",") + ")"
+}
+
+// arg adds a query argument and returns its placeholder.
+func (f *rentalsFilter) arg(value interface{}) string {
+	f.args = append(f.args, value)
+	return fmt.Sprintf("$%d", len(f.args))
+}
+
+func (f *rentalsFilter) where(condition string) {
+	f.conditions = append(f.conditions, condition)
+}
+
+// fromClause selects the rentals matching the filter together with their owners.
+func (f *rentalsFilter) fromClause() string {
+	return `
+		FROM rentals AS r
+		JOIN users ON r.user_id = users.id` + f.whereClause()
+}
+
+// whereClause joins all conditions into a WHERE clause, or returns an empty string without conditions.
+func (f *rentalsFilter) whereClause() string {
+	if len(f.conditions) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(f.conditions, " AND ")
+}
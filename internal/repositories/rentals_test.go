@@ -2,7 +2,9 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/toshko07/outdoorsy-challenge/internal/models"
@@ -29,7 +31,7 @@ func TestRetails_GetRetail(t *testing.T) {
 				Length:          15,
 				Sleeps:          4,
 				PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1528586451/p/rentals/4447/images/yd7txtw4hnkjvklg8edg.jpg",
-				Price:           models.Price{PerDay: 16900},
+				Price:           models.Price{PerDay: 16900, Currency: "USD"},
 				Location: models.Location{
 					City:    "Costa Mesa",
 					State:   "CA",
@@ -38,7 +40,9 @@ func TestRetails_GetRetail(t *testing.T) {
 					Lat:     33.64,
 					Lng:     -117.93,
 				},
-				User: models.User{Id: 1, FirstName: "John", LastName: "Smith"},
+				User:    models.User{Id: 1, FirstName: "John", LastName: "Smith"},
+				Created: seedTimestamp,
+				Updated: seedTimestamp,
 			},
 			expected: nil,
 		},
@@ -46,7 +50,7 @@ func TestRetails_GetRetail(t *testing.T) {
 			name:           "Get non-existing rental",
 			id:             404,
 			expectedRental: nil,
-			expected:       models.NewNotFoundError("rental with id 404 not found"),
+			expected:       models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 404 not found"),
 		},
 	}
 
@@ -89,9 +93,11 @@ func TestRetails_GetRentals_ByIDs(t *testing.T) {
 					Length:          15,
 					Sleeps:          4,
 					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1528586451/p/rentals/4447/images/yd7txtw4hnkjvklg8edg.jpg",
-					Price:           models.Price{PerDay: 16900},
+					Price:           models.Price{PerDay: 16900, Currency: "USD"},
 					Location:        models.Location{City: "Costa Mesa", State: "CA", Zip: "92627", Country: "US", Lat: 33.64, Lng: -117.93},
 					User:            models.User{Id: 1, FirstName: "John", LastName: "Smith"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
 				},
 				{
 					Id: 2, Name: "Maupin: Vanagon Camper",
@@ -103,9 +109,11 @@ func TestRetails_GetRentals_ByIDs(t *testing.T) {
 					Length:          15,
 					Sleeps:          4,
 					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1498568017/p/rentals/11368/images/gmtye6p2eq61v0g7f7e7.jpg",
-					Price:           models.Price{PerDay: 15000},
+					Price:           models.Price{PerDay: 15000, Currency: "USD"},
 					Location:        models.Location{City: "Portland", State: "OR", Zip: "97202", Country: "US", Lat: 45.51, Lng: -122.68},
 					User:            models.User{Id: 2, FirstName: "Jane", LastName: "Doe"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
 				},
 			},
 			expectedError:  nil,
@@ -162,9 +170,11 @@ func TestRetails_GetRentals_ByPrice(t *testing.T) {
 					Length:          17,
 					Sleeps:          2,
 					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1550269521/p/rentals/108507/images/zlruuz6ll72taorfwjs1.jpg",
-					Price:           models.Price{PerDay: 3000},
+					Price:           models.Price{PerDay: 3000, Currency: "USD"},
 					Location:        models.Location{City: "Kihei", State: "HI", Zip: "96753", Country: "US", Lat: 20.77, Lng: -156.45},
 					User:            models.User{Id: 2, FirstName: "Jane", LastName: "Doe"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
 				},
 			},
 			expectedError:  nil,
@@ -197,13 +207,81 @@ func TestRetails_GetRentals_ByPrice(t *testing.T) {
 	}
 }
 
+func TestRetails_GetRentals_ByAttributes(t *testing.T) {
+	testCases := []struct {
+		name        string
+		params      models.GetRentalsParams
+		expectedIds []int
+	}{
+		{
+			name:        "Make regardless of case",
+			params:      models.GetRentalsParams{Make: "TOYOTA"},
+			expectedIds: []int{17, 18, 26},
+		},
+		{
+			name:        "Make and model",
+			params:      models.GetRentalsParams{Make: "ford", Model: "transit"},
+			expectedIds: []int{19, 24, 27},
+		},
+		{
+			name:        "Any of the types",
+			params:      models.GetRentalsParams{Types: []string{"trailer", "camper-van"}, Make: "Peugeot"},
+			expectedIds: []int{21},
+		},
+		{
+			name:        "Unknown type",
+			params:      models.GetRentalsParams{Types: []string{"trailer"}},
+			expectedIds: []int{},
+		},
+		{
+			name:        "Year range",
+			params:      models.GetRentalsParams{YearMin: 2015, YearMax: 2017},
+			expectedIds: []int{4, 8, 17, 19, 21, 24, 29},
+		},
+		{
+			name:        "Minimum sleeps",
+			params:      models.GetRentalsParams{SleepsMin: 5},
+			expectedIds: []int{26},
+		},
+		{
+			name:        "Length range",
+			params:      models.GetRentalsParams{LengthMin: 20, LengthMax: 21},
+			expectedIds: []int{5, 8, 19, 23, 27, 29, 30},
+		},
+		{
+			name:        "Minimum sleeps and maximum length",
+			params:      models.GetRentalsParams{SleepsMin: 4, LengthMax: 15},
+			expectedIds: []int{1, 2, 7, 10, 11, 15, 26},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewRentalsRepo(database)
+
+			// When
+			rentals, err := repo.GetRentals(ctx, tc.params)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedIds, rentalIds(rentals))
+		})
+	}
+}
+
 func TestRetails_GetRentals_ByLocation(t *testing.T) {
 	testCases := []struct {
-		name           string
-		near           []float64
-		expected       []models.Rental
-		expectedError  error
-		expectedLength int
+		name              string
+		near              []float64
+		radius            float64
+		unit              string
+		expected          []models.Rental
+		expectedDistances []float64
+		expectedError     error
+		expectedLength    int
 	}{
 		{
 			name: "Get existing rentals by location",
@@ -220,13 +298,52 @@ func TestRetails_GetRentals_ByLocation(t *testing.T) {
 					Length:          4.8,
 					Sleeps:          2,
 					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1566292990/p/rentals/137450/images/m1axdiiyampit2da6ufu.jpg",
-					Price:           models.Price{PerDay: 9000},
+					Price:           models.Price{PerDay: 9000, Currency: "GBP"},
+					Location:        models.Location{City: "Cumbria", State: "CMA", Zip: "CA11 9TE", Country: "GB", Lat: 54.72, Lng: -2.88},
+					User:            models.User{Id: 1, FirstName: "John", LastName: "Smith"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
+				},
+			},
+			expectedDistances: []float64{2.88},
+			expectedError:     nil,
+			expectedLength:    1,
+		},
+		{
+			name:   "Get existing rentals by location within a radius in kilometers",
+			near:   []float64{54.71, -2.81},
+			radius: 5,
+			unit:   models.DistanceUnitKilometers,
+			expected: []models.Rental{
+				{
+					Id:              21,
+					Name:            "2013 Peugeot Expert SWB",
+					Description:     "sem vitae bibendum hendrerit sapien nulla convallis tempus gravida eu libero litora vulputate tempus nulla ac molestie consequat dictum nisl aptent ligula lacus senectus sagittis",
+					Type:            "camper-van",
+					Make:            "Peugeot",
+					Model:           "Expert SWB",
+					Year:            2015,
+					Length:          4.8,
+					Sleeps:          2,
+					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1566292990/p/rentals/137450/images/m1axdiiyampit2da6ufu.jpg",
+					Price:           models.Price{PerDay: 9000, Currency: "GBP"},
 					Location:        models.Location{City: "Cumbria", State: "CMA", Zip: "CA11 9TE", Country: "GB", Lat: 54.72, Lng: -2.88},
 					User:            models.User{Id: 1, FirstName: "John", LastName: "Smith"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
 				},
 			},
+			expectedDistances: []float64{4.63},
+			expectedError:     nil,
+			expectedLength:    1,
+		},
+		{
+			name:           "Get non-existing rentals by location within a small radius",
+			near:           []float64{54.71, -2.81},
+			radius:         2,
+			expected:       nil,
 			expectedError:  nil,
-			expectedLength: 1,
+			expectedLength: 0,
 		},
 		{
 			name:           "Get non-existing rentals by location",
@@ -235,6 +352,221 @@ func TestRetails_GetRentals_ByLocation(t *testing.T) {
 			expectedError:  nil,
 			expectedLength: 0,
 		},
+		{
+			name:           "Unknown distance unit",
+			near:           []float64{54.71, -2.81},
+			unit:           "ft",
+			expected:       nil,
+			expectedError:  models.NewBadRequestError("unknown distance unit 'ft'"),
+			expectedLength: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, geography := range []bool{true, false} {
+			geography := geography
+			t.Run(fmt.Sprintf("%s (geography: %t)", tc.name, geography), func(t *testing.T) {
+				// Given
+				ctx := context.Background()
+				repo := &RentalsImpl{db: database, geography: &geography, geographyCheckedAt: time.Now()}
+
+				// When
+				rentals, err := repo.GetRentals(ctx, models.GetRentalsParams{Near: tc.near, Radius: tc.radius, Unit: tc.unit})
+
+				// Then
+				assert.Equal(t, tc.expectedError, err)
+				assert.Equal(t, tc.expectedLength, len(rentals))
+				assertDistances(t, tc.expectedDistances, rentals)
+				assert.Equal(t, tc.expected, rentals)
+			})
+		}
+	}
+}
+
+// assertDistances checks the computed distances with a tolerance and clears them, so the rentals can be compared exactly.
+func assertDistances(t *testing.T, expected []float64, rentals []models.Rental) {
+	for i := range rentals {
+		if assert.NotNil(t, rentals[i].Distance) && i < len(expected) {
+			assert.InDelta(t, expected[i], *rentals[i].Distance, 0.01)
+		}
+		rentals[i].Distance = nil
+	}
+}
+
+func TestRetails_GetRentals_ByUser(t *testing.T) {
+	testCases := []struct {
+		name        string
+		params      models.GetRentalsParams
+		expectedIds []int
+	}{
+		{
+			name:        "Get the rentals of a user",
+			params:      models.GetRentalsParams{UserId: 4, Sort: []models.SortField{{Field: models.SortFieldPrice}}},
+			expectedIds: []int{9, 4, 24, 19, 29, 14},
+		},
+		{
+			name:        "Get the rentals of a user by price",
+			params:      models.GetRentalsParams{UserId: 4, PriceMin: 10000, PriceMax: 20000, Sort: []models.SortField{{Field: models.SortFieldPrice}}},
+			expectedIds: []int{24, 19, 29},
+		},
+		{
+			name:        "Get the rentals of a user without rentals",
+			params:      models.GetRentalsParams{UserId: 404},
+			expectedIds: []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewRentalsRepo(database)
+
+			// When
+			rentals, err := repo.GetRentals(ctx, tc.params)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedIds, rentalIds(rentals))
+		})
+	}
+}
+
+func TestRetails_GetRentals_AvailableBetweenDates(t *testing.T) {
+	testCases := []struct {
+		name        string
+		dates       models.DateRange
+		expectedIds []int
+	}{
+		{
+			name:        "Exclude booked and blocked rentals",
+			dates:       models.DateRange{Start: time.Date(2030, 7, 5, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 7, 6, 0, 0, 0, 0, time.UTC)},
+			expectedIds: []int{2},
+		},
+		{
+			name:        "Check-in on the check-out day of a booking",
+			dates:       models.DateRange{Start: time.Date(2030, 7, 8, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 7, 10, 0, 0, 0, 0, time.UTC)},
+			expectedIds: []int{1, 2},
+		},
+		{
+			name:        "Check-out on the first blocked day",
+			dates:       models.DateRange{Start: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 7, 5, 0, 0, 0, 0, time.UTC)},
+			expectedIds: []int{2, 3},
+		},
+		{
+			name:        "Dates without bookings",
+			dates:       models.DateRange{Start: time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 8, 8, 0, 0, 0, 0, time.UTC)},
+			expectedIds: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewRentalsRepo(database)
+
+			// When
+			rentals, err := repo.GetRentals(ctx, models.GetRentalsParams{Ids: []int{1, 2, 3}, Available: &tc.dates})
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedIds, rentalIds(rentals))
+		})
+	}
+}
+
+func TestRetails_GetRentals_InCurrency(t *testing.T) {
+	// The units of euro one unit of every currency buys.
+	rates := map[string]float64{"EUR": 1, "USD": 0.9, "CAD": 0.72, "GBP": 1.125, "AUD": 0.5625}
+	withoutAUD := map[string]float64{"EUR": 1, "USD": 0.9, "CAD": 0.72, "GBP": 1.125}
+	byPrice := []models.SortField{{Field: models.SortFieldPrice}}
+
+	testCases := []struct {
+		name        string
+		params      models.GetRentalsParams
+		expectedIds []int
+	}{
+		{
+			name:        "Sort by the converted price",
+			params:      models.GetRentalsParams{Currency: "EUR", ExchangeRates: rates, Sort: byPrice},
+			expectedIds: []int{26, 24, 11, 21, 14},
+		},
+		{
+			name:        "Filter by the converted price",
+			params:      models.GetRentalsParams{Currency: "EUR", ExchangeRates: rates, PriceMin: 7560, PriceMax: 8900, Sort: byPrice},
+			expectedIds: []int{24, 11},
+		},
+		{
+			name:        "Exclude rentals in a currency without an exchange rate",
+			params:      models.GetRentalsParams{Currency: "EUR", ExchangeRates: withoutAUD, Sort: byPrice},
+			expectedIds: []int{24, 11, 21, 14},
+		},
+		{
+			name:        "Filter by the native price without a currency",
+			params:      models.GetRentalsParams{PriceMax: 10500, Sort: byPrice},
+			expectedIds: []int{11, 21, 24},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewRentalsRepo(database)
+			tc.params.Ids = []int{11, 14, 21, 24, 26}
+
+			// When
+			rentals, err := repo.GetRentals(ctx, tc.params)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedIds, rentalIds(rentals))
+		})
+	}
+}
+
+func TestRetails_GetUnavailablePeriods(t *testing.T) {
+	testCases := []struct {
+		name     string
+		id       int
+		dates    models.DateRange
+		expected []models.UnavailablePeriod
+	}{
+		{
+			name:  "Bookings clipped to the range",
+			id:    1,
+			dates: models.DateRange{Start: time.Date(2030, 7, 5, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 7, 21, 0, 0, 0, 0, time.UTC)},
+			expected: []models.UnavailablePeriod{
+				{
+					DateRange: models.DateRange{Start: time.Date(2030, 7, 5, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 7, 8, 0, 0, 0, 0, time.UTC)},
+					Reason:    models.UnavailableReasonBooked,
+				},
+				{
+					DateRange: models.DateRange{Start: time.Date(2030, 7, 20, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 7, 21, 0, 0, 0, 0, time.UTC)},
+					Reason:    models.UnavailableReasonBooked,
+				},
+			},
+		},
+		{
+			name:     "Cancelled bookings are ignored",
+			id:       2,
+			dates:    models.DateRange{Start: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC)},
+			expected: nil,
+		},
+		{
+			name:  "Blocked dates",
+			id:    3,
+			dates: models.DateRange{Start: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC)},
+			expected: []models.UnavailablePeriod{
+				{
+					DateRange: models.DateRange{Start: time.Date(2030, 7, 5, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 7, 10, 0, 0, 0, 0, time.UTC)},
+					Reason:    models.UnavailableReasonBlocked,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -244,12 +576,400 @@ func TestRetails_GetRentals_ByLocation(t *testing.T) {
 			repo := NewRentalsRepo(database)
 
 			// When
-			rentals, err := repo.GetRentals(ctx, models.GetRentalsParams{Near: tc.near})
+			periods, err := repo.GetUnavailablePeriods(ctx, tc.id, tc.dates)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, periods)
+		})
+	}
+}
+
+func TestRetails_GetRentals_SortByDistance(t *testing.T) {
+	for _, geography := range []bool{true, false} {
+		geography := geography
+		t.Run(fmt.Sprintf("geography: %t", geography), func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := &RentalsImpl{db: database, geography: &geography, geographyCheckedAt: time.Now()}
+
+			// When
+			rentals, err := repo.GetRentals(ctx, models.GetRentalsParams{
+				Ids:  []int{3, 7, 23},
+				Near: []float64{33.64, -117.93},
+				Sort: []models.SortField{{Field: models.SortFieldDistance}},
+			})
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, []int{7, 3, 23}, rentalIds(rentals))
+		})
+	}
+}
+
+func TestRetails_GetRentals_ByQuery(t *testing.T) {
+	testCases := []struct {
+		name        string
+		query       string
+		expectedIds []int
+	}{
+		{
+			name:        "Match regardless of case",
+			query:       "TOYOTA",
+			expectedIds: []int{17, 18, 26},
+		},
+		{
+			name:        "Match a make with a typo",
+			query:       "volkswagon",
+			expectedIds: []int{1, 2, 3, 10, 11, 15},
+		},
+		{
+			name:        "Match a make by its alias",
+			query:       "vw",
+			expectedIds: []int{1, 2, 3, 7, 10, 11, 15},
+		},
+		{
+			name:        "Match the alias of a make",
+			query:       "chevy",
+			expectedIds: []int{20, 23},
+		},
+		{
+			name:        "Match all words",
+			query:       "volkswagen westfalia",
+			expectedIds: []int{1, 3, 7, 10, 15},
+		},
+		{
+			name:        "Match nothing",
+			query:       "airstream",
+			expectedIds: []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewRentalsRepo(database)
+
+			// When
+			rentals, err := repo.GetRentals(ctx, models.GetRentalsParams{Query: tc.query})
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedIds, rentalIds(rentals))
+		})
+	}
+}
+
+func TestRetails_GetRentals_SortByRelevance(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewRentalsRepo(database)
+
+	// When
+	rentals, err := repo.GetRentals(ctx, models.GetRentalsParams{
+		Query: "westfalia",
+		Sort:  []models.SortField{{Field: models.SortFieldRelevance}},
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int{1, 3, 7, 10, 15}, rentalIds(rentals))
+	for i := 1; i < len(rentals); i++ {
+		assert.GreaterOrEqual(t, *rentals[i-1].Relevance, *rentals[i].Relevance)
+	}
+}
+
+func TestRetails_GetRentals_AfterCursor(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params models.GetRentalsParams
+	}{
+		{
+			name:   "Sort by id",
+			params: models.GetRentalsParams{},
+		},
+		{
+			name:   "Sort by price",
+			params: models.GetRentalsParams{Sort: []models.SortField{{Field: models.SortFieldPrice}}},
+		},
+		{
+			name:   "Sort by year descending and length",
+			params: models.GetRentalsParams{Sort: []models.SortField{{Field: models.SortFieldYear, Desc: true}, {Field: models.SortFieldLength}}},
+		},
+		{
+			name:   "Sort by created and name descending",
+			params: models.GetRentalsParams{Sort: []models.SortField{{Field: models.SortFieldCreated}, {Field: models.SortFieldName, Desc: true}}},
+		},
+		{
+			name: "Sort by distance",
+			params: models.GetRentalsParams{
+				Near:   []float64{33.64, -117.93},
+				Radius: 3000,
+				Sort:   []models.SortField{{Field: models.SortFieldDistance}},
+			},
+		},
+		{
+			name:   "Sort by relevance and price",
+			params: models.GetRentalsParams{Query: "van", Sort: []models.SortField{{Field: models.SortFieldRelevance}, {Field: models.SortFieldPrice}}},
+		},
+		{
+			name:   "Sort by relevance ascending",
+			params: models.GetRentalsParams{Query: "van", Sort: []models.SortField{{Field: models.SortFieldRelevance, Desc: true}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewRentalsRepo(database)
+			all, err := repo.GetRentals(ctx, tc.params)
+			assert.NoError(t, err)
+
+			// When
+			var paged []models.Rental
+			params := tc.params
+			params.Limit = 4
+			for {
+				rentals, err := repo.GetRentals(ctx, params)
+				assert.NoError(t, err)
+				if len(rentals) == 0 {
+					break
+				}
+				paged = append(paged, rentals...)
+				params.After = models.NewCursor(params, rentals[len(rentals)-1])
+			}
+
+			// Then
+			assert.NotEmpty(t, all)
+			assert.Equal(t, all, paged)
+		})
+	}
+}
+
+func TestRetails_GetRentals_ByArea(t *testing.T) {
+	testCases := []struct {
+		name          string
+		params        models.GetRentalsParams
+		geography     bool
+		expectedIds   []int
+		expectedError error
+	}{
+		{
+			name:        "Bounding box",
+			params:      models.GetRentalsParams{BBox: &models.BoundingBox{MinLng: -118.5, MinLat: 32.5, MaxLng: -117, MaxLat: 34.5}},
+			geography:   true,
+			expectedIds: []int{1, 3, 5, 7, 15, 23},
+		},
+		{
+			name:        "Bounding box crossing the antimeridian",
+			params:      models.GetRentalsParams{BBox: &models.BoundingBox{MinLng: 110, MinLat: -40, MaxLng: -150, MaxLat: 25}},
+			geography:   false,
+			expectedIds: []int{9, 12, 20, 25, 26},
+		},
+		{
+			name: "Polygon",
+			params: models.GetRentalsParams{Polygons: []models.Polygon{
+				{{{-118.5, 32.5}, {-117, 32.5}, {-117, 34.5}, {-118.5, 34.5}, {-118.5, 32.5}}},
+			}},
+			geography:   true,
+			expectedIds: []int{1, 3, 5, 7, 15, 23},
+		},
+		{
+			name: "Polygon crossing the antimeridian",
+			params: models.GetRentalsParams{Polygons: []models.Polygon{
+				{{{170, 15}, {-150, 15}, {-150, 25}, {170, 25}, {170, 15}}},
+			}},
+			geography:   true,
+			expectedIds: []int{9, 12, 20, 25},
+		},
+		{
+			name: "Multi polygon split at the antimeridian",
+			params: models.GetRentalsParams{Polygons: []models.Polygon{
+				{{{110, -40}, {180, -40}, {180, 25}, {110, 25}, {110, -40}}},
+				{{{-180, -40}, {-150, -40}, {-150, 25}, {-180, 25}, {-180, -40}}},
+			}},
+			geography:   true,
+			expectedIds: []int{9, 12, 20, 25, 26},
+		},
+		{
+			name: "Polygon without PostGIS",
+			params: models.GetRentalsParams{Polygons: []models.Polygon{
+				{{{170, 15}, {-150, 15}, {-150, 25}, {170, 25}, {170, 15}}},
+			}},
+			geography:     false,
+			expectedError: models.NewUnavailableError("polygon search requires the PostGIS geog column", nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := &RentalsImpl{db: database, geography: &tc.geography, geographyCheckedAt: time.Now()}
+
+			// When
+			rentals, err := repo.GetRentals(ctx, tc.params)
 
 			// Then
 			assert.Equal(t, tc.expectedError, err)
-			assert.Equal(t, tc.expectedLength, len(rentals))
-			assert.Equal(t, tc.expected, rentals)
+			var ids []int
+			for _, rental := range rentals {
+				ids = append(ids, rental.Id)
+			}
+			assert.Equal(t, tc.expectedIds, ids)
+		})
+	}
+}
+
+func TestRetails_HasGeography(t *testing.T) {
+	testCases := []struct {
+		name              string
+		geography         *bool
+		checkedAt         time.Time
+		expectedGeography bool
+	}{
+		{
+			name:              "Not checked yet",
+			expectedGeography: true,
+		},
+		{
+			name:              "Missing column checked recently",
+			geography:         new(bool),
+			checkedAt:         time.Now(),
+			expectedGeography: false,
+		},
+		{
+			name:              "Missing column checked before the recheck",
+			geography:         new(bool),
+			checkedAt:         time.Now().Add(-geographyRecheck),
+			expectedGeography: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := &RentalsImpl{db: database, geography: tc.geography, geographyCheckedAt: tc.checkedAt}
+
+			// When
+			geography, err := repo.hasGeography(ctx)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedGeography, geography)
+		})
+	}
+}
+
+func TestRetails_CountRentals(t *testing.T) {
+	testCases := []struct {
+		name          string
+		params        models.GetRentalsParams
+		expected      int
+		expectedError error
+	}{
+		{
+			name:     "Count all rentals ignoring pagination",
+			params:   models.GetRentalsParams{Limit: 2, Offset: 3, Sort: []models.SortField{{Field: models.SortFieldPrice}}},
+			expected: 30,
+		},
+		{
+			name:     "Count rentals by price",
+			params:   models.GetRentalsParams{PriceMin: 20000},
+			expected: 4,
+		},
+		{
+			name:     "Count rentals by price and location",
+			params:   models.GetRentalsParams{PriceMin: 9000, PriceMax: 75000, Near: []float64{33.64, -117.93}},
+			expected: 5,
+		},
+		{
+			name:          "Unknown distance unit",
+			params:        models.GetRentalsParams{Near: []float64{33.64, -117.93}, Unit: "ft"},
+			expected:      0,
+			expectedError: models.NewBadRequestError("unknown distance unit 'ft'"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewRentalsRepo(database)
+
+			// When
+			count, err := repo.CountRentals(ctx, tc.params)
+
+			// Then
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expected, count)
+		})
+	}
+}
+
+func TestRetails_GetFacets(t *testing.T) {
+	testCases := []struct {
+		name           string
+		params         models.GetRentalsParams
+		expectedFacets *models.RentalFacets
+	}{
+		{
+			name:   "Make regardless of case",
+			params: models.GetRentalsParams{Make: "toyota"},
+			expectedFacets: &models.RentalFacets{
+				Total:     3,
+				Types:     []models.FacetCount{{Value: "camper-van", Count: 3}},
+				Makes:     []models.FacetCount{{Value: "Toyota", Count: 3}},
+				States:    []models.FacetCount{{Value: "AU-WA", Count: 1}, {Value: "US-AK", Count: 1}, {Value: "US-CO", Count: 1}},
+				Countries: []models.FacetCount{{Value: "US", Count: 2}, {Value: "AU", Count: 1}},
+				Sleeps:    []models.FacetCount{{Value: "2", Count: 1}, {Value: "4", Count: 1}, {Value: "5", Count: 1}},
+				Price: &models.PriceStats{
+					Min:    11000,
+					Max:    19900,
+					P25:    11000,
+					Median: 13500,
+					P75:    19900,
+					Histogram: []models.PriceBucket{
+						{Min: 11000, Max: 11891, Count: 1},
+						{Min: 11891, Max: 12782, Count: 0},
+						{Min: 12782, Max: 13673, Count: 1},
+						{Min: 13673, Max: 14564, Count: 0},
+						{Min: 14564, Max: 15455, Count: 0},
+						{Min: 15455, Max: 16346, Count: 0},
+						{Min: 16346, Max: 17237, Count: 0},
+						{Min: 17237, Max: 18128, Count: 0},
+						{Min: 18128, Max: 19019, Count: 0},
+						{Min: 19019, Max: 19910, Count: 1},
+					},
+				},
+			},
+		},
+		{
+			name:           "No matching rentals",
+			params:         models.GetRentalsParams{Types: []string{"trailer"}},
+			expectedFacets: &models.RentalFacets{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewRentalsRepo(database)
+
+			// When
+			facets, err := repo.GetFacets(ctx, tc.params)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFacets, facets)
 		})
 	}
 }
@@ -257,7 +977,7 @@ func TestRetails_GetRentals_ByLocation(t *testing.T) {
 func TestRetails_GetRentals_Sort_Offset_Limit(t *testing.T) {
 	testCases := []struct {
 		name           string
-		sort           string
+		sort           []models.SortField
 		offset         int
 		limit          int
 		expected       []models.Rental
@@ -266,7 +986,7 @@ func TestRetails_GetRentals_Sort_Offset_Limit(t *testing.T) {
 	}{
 		{
 			name:   "Sort by price, offset 1, limit 2",
-			sort:   "price",
+			sort:   []models.SortField{{Field: models.SortFieldPrice}},
 			offset: 1,
 			limit:  2,
 			expected: []models.Rental{
@@ -281,9 +1001,11 @@ func TestRetails_GetRentals_Sort_Offset_Limit(t *testing.T) {
 					Length:          13,
 					Sleeps:          2,
 					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1538027810/p/rentals/82458/images/bphrohl2r4wxc8wg3v11.jpg",
-					Price:           models.Price{PerDay: 5900},
+					Price:           models.Price{PerDay: 5900, Currency: "USD"},
 					Location:        models.Location{City: "Kahului", State: "HI", Zip: "96732", Country: "US", Lat: 20.88, Lng: -156.45},
 					User:            models.User{Id: 4, FirstName: "Todd", LastName: "Edison"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
 				},
 				{
 					Id:              13,
@@ -296,14 +1018,67 @@ func TestRetails_GetRentals_Sort_Offset_Limit(t *testing.T) {
 					Length:          16,
 					Sleeps:          4,
 					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1556142483/p/rentals/109101/images/ea2vvbovq0tvouj00fad.jpg",
-					Price:           models.Price{PerDay: 7900},
+					Price:           models.Price{PerDay: 7900, Currency: "USD"},
 					Location:        models.Location{City: "Provo", State: "UT", Zip: "84601", Country: "US", Lat: 40.24, Lng: -111.7},
 					User:            models.User{Id: 3, FirstName: "Barry", LastName: "Martin"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
+				},
+			},
+			expectedError:  nil,
+			expectedLength: 2,
+		},
+		{
+			name:   "Sort by year descending and price, limit 2",
+			sort:   []models.SortField{{Field: models.SortFieldYear, Desc: true}, {Field: models.SortFieldPrice}},
+			offset: 0,
+			limit:  2,
+			expected: []models.Rental{
+				{
+					Id:              28,
+					Name:            "sCAMPer X",
+					Description:     "ac tellus phasellus ultrices nostra eros aenean metus ridiculus adipiscing habitant nulla cubilia tortor rhoncus quisque sem ultrices varius massa mollis congue praesent nam ante",
+					Type:            "camper-van",
+					Make:            "Ram",
+					Model:           "Promaster",
+					Year:            2020,
+					Length:          19,
+					Sleeps:          4,
+					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1589910541/p/rentals/156152/images/jvyvtqoeljadoizjjzag.jpg",
+					Price:           models.Price{PerDay: 17500, Currency: "USD"},
+					Location:        models.Location{City: "Atlanta", State: "GA", Zip: "30310", Country: "US", Lat: 33.73, Lng: -84.41},
+					User:            models.User{Id: 3, FirstName: "Barry", LastName: "Martin"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
+				},
+				{
+					Id:              27,
+					Name:            "Coya | Van-gelina Jolie",
+					Description:     "lacus cras molestie nam dapibus ullamcorper massa ultricies bibendum lectus auctor nisi ridiculus ultricies tristique curabitur diam feugiat erat inceptos sapien vivamus parturient sem nibh",
+					Type:            "camper-van",
+					Make:            "Ford",
+					Model:           "Transit",
+					Year:            2019,
+					Length:          20,
+					Sleeps:          2,
+					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1582091293/p/rentals/153401/images/kaqt2b6n6sm1xnmvbi5w.jpg",
+					Price:           models.Price{PerDay: 20000, Currency: "USD"},
+					Location:        models.Location{City: "Seattle", State: "WA", Zip: "98116", Country: "US", Lat: 47.56, Lng: -122.39},
+					User:            models.User{Id: 2, FirstName: "Jane", LastName: "Doe"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
 				},
 			},
 			expectedError:  nil,
 			expectedLength: 2,
 		},
+		{
+			name:           "Sort by distance without near",
+			sort:           []models.SortField{{Field: models.SortFieldDistance}},
+			expected:       nil,
+			expectedError:  models.NewBadRequestError("can not sort rentals by 'distance'"),
+			expectedLength: 0,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -325,17 +1100,18 @@ func TestRetails_GetRentals_Sort_Offset_Limit(t *testing.T) {
 
 func TestRetails_GetRentals_FilterByAllQueries(t *testing.T) {
 	testCases := []struct {
-		name           string
-		ids            []int
-		priceMin       int64
-		priceMax       int64
-		near           []float64
-		sort           string
-		offset         int
-		limit          int
-		expected       []models.Rental
-		expectedError  error
-		expectedLength int
+		name              string
+		ids               []int
+		priceMin          int64
+		priceMax          int64
+		near              []float64
+		sort              []models.SortField
+		offset            int
+		limit             int
+		expected          []models.Rental
+		expectedDistances []float64
+		expectedError     error
+		expectedLength    int
 	}{
 		{
 			name:     "Get existing rentals by ids, near by location, sort by year, offset 2, limit 2",
@@ -343,7 +1119,7 @@ func TestRetails_GetRentals_FilterByAllQueries(t *testing.T) {
 			priceMin: 9000,
 			priceMax: 75000,
 			near:     []float64{33.64, -117.93},
-			sort:     "year",
+			sort:     []models.SortField{{Field: models.SortFieldYear}},
 			offset:   2,
 			limit:    3,
 			expected: []models.Rental{
@@ -357,9 +1133,11 @@ func TestRetails_GetRentals_FilterByAllQueries(t *testing.T) {
 					Length:          16,
 					Sleeps:          4,
 					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1504395813/p/rentals/21399/images/nxtwdubpapgpmuc65pd1.jpg",
-					Price:           models.Price{PerDay: 18000},
+					Price:           models.Price{PerDay: 18000, Currency: "USD"},
 					Location:        models.Location{City: "San Diego", State: "CA", Zip: "92037", Country: "US", Lat: 32.83, Lng: -117.28},
 					User:            models.User{Id: 3, FirstName: "Barry", LastName: "Martin"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
 				},
 				{
 					Id: 7, Name: "2002 Volkswagen Eurovan Weekender Westfalia",
@@ -371,9 +1149,11 @@ func TestRetails_GetRentals_FilterByAllQueries(t *testing.T) {
 					Length:          0,
 					Sleeps:          4,
 					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1526614056/p/rentals/52210/images/nou2lx0h0dsjzbqeotuf.jpg",
-					Price:           models.Price{PerDay: 15000},
+					Price:           models.Price{PerDay: 15000, Currency: "USD"},
 					Location:        models.Location{City: "Rancho Mission Viejo", State: "CA", Zip: "", Country: "US", Lat: 33.53, Lng: -117.63},
 					User:            models.User{Id: 2, FirstName: "Jane", LastName: "Doe"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
 				},
 				{
 					Id: 23, Name: "2002 Chevrolet Van Conversion",
@@ -385,13 +1165,16 @@ func TestRetails_GetRentals_FilterByAllQueries(t *testing.T) {
 					Length:          21,
 					Sleeps:          2,
 					PrimaryImageUrl: "https://res.cloudinary.com/outdoorsy/image/upload/v1569722222/p/rentals/143740/images/ooxoce0zrlycj5esm3jh.png",
-					Price:           models.Price{PerDay: 9900},
+					Price:           models.Price{PerDay: 9900, Currency: "USD"},
 					Location:        models.Location{City: "San Diego", State: "CA", Zip: "92107", Country: "US", Lat: 32.73, Lng: -117.24},
 					User:            models.User{Id: 3, FirstName: "Barry", LastName: "Martin"},
+					Created:         seedTimestamp,
+					Updated:         seedTimestamp,
 				},
 			},
-			expectedError:  nil,
-			expectedLength: 3,
+			expectedDistances: []float64{67.41, 18.87, 74.47},
+			expectedError:     nil,
+			expectedLength:    3,
 		},
 	}
 
@@ -407,7 +1190,87 @@ func TestRetails_GetRentals_FilterByAllQueries(t *testing.T) {
 			// Then
 			assert.Equal(t, tc.expectedError, err)
 			assert.Equal(t, tc.expectedLength, len(rentals))
+			assertDistances(t, tc.expectedDistances, rentals)
 			assert.Equal(t, tc.expected, rentals)
 		})
 	}
 }
+
+func TestRetails_CreateUpdateDeleteRental(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewRentalsRepo(database)
+	rental := models.Rental{
+		Name:            "Test Rental",
+		Description:     "Test Description",
+		Type:            "camper-van",
+		Make:            "Volkswagen",
+		Model:           "Westfalia",
+		Year:            1984,
+		Length:          16.5,
+		Sleeps:          4,
+		PrimaryImageUrl: "https://example.com/image.jpg",
+		Price:           models.Price{PerDay: 18000, Currency: "USD"},
+		Location:        models.Location{City: "San Diego", State: "CA", Zip: "92037", Country: "US", Lat: 32.83, Lng: -117.28},
+		User:            models.User{Id: 3},
+	}
+
+	// When
+	id, err := repo.CreateRental(ctx, rental)
+	t.Cleanup(func() {
+		_, _ = database.Exec("DELETE FROM rentals WHERE id = $1", id)
+	})
+
+	// Then
+	assert.NoError(t, err)
+	created, err := repo.GetRental(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.User{Id: 3, FirstName: "Barry", LastName: "Martin"}, created.User)
+	assert.Equal(t, rental.Location, created.Location)
+	assert.Equal(t, rental.Length, created.Length)
+	assert.False(t, created.Created.IsZero())
+	assert.Equal(t, created.Created, created.Updated)
+
+	near, err := repo.GetRentals(ctx, models.GetRentalsParams{Near: []float64{32.83, -117.28}, Radius: 1})
+	assert.NoError(t, err)
+	assert.Contains(t, rentalIds(near), id)
+
+	// When
+	rental.Id = id
+	rental.Name = "Updated Rental"
+	rental.Location.Lat, rental.Location.Lng = 20.88, -156.45
+	rental.Price = models.Price{PerDay: 15000, Currency: "EUR"}
+	err = repo.UpdateRental(ctx, rental)
+
+	// Then
+	assert.NoError(t, err)
+	updated, err := repo.GetRental(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated Rental", updated.Name)
+	assert.Equal(t, rental.Price, updated.Price)
+	assert.Equal(t, created.Created, updated.Created)
+	assert.True(t, updated.Updated.After(created.Updated))
+
+	near, err = repo.GetRentals(ctx, models.GetRentalsParams{Near: []float64{20.88, -156.45}, Radius: 1})
+	assert.NoError(t, err)
+	assert.Contains(t, rentalIds(near), id)
+
+	// When
+	err = repo.DeleteRental(ctx, id)
+
+	// Then
+	assert.NoError(t, err)
+	_, err = repo.GetRental(ctx, id)
+	assert.Equal(t, models.NewNotFoundError(models.RentalNotFoundCode, fmt.Sprintf("rental with id %d not found", id)), err)
+	assert.Equal(t, models.NewNotFoundError(models.RentalNotFoundCode, fmt.Sprintf("rental with id %d not found", id)), repo.DeleteRental(ctx, id))
+	assert.Equal(t, models.NewNotFoundError(models.RentalNotFoundCode, fmt.Sprintf("rental with id %d not found", id)), repo.UpdateRental(ctx, rental))
+}
+
+func rentalIds(rentals []models.Rental) []int {
+	ids := make([]int, len(rentals))
+	for i, rental := range rentals {
+		ids[i] = rental.Id
+	}
+
+	return ids
+}
@@ -4,12 +4,16 @@ import (
 	"database/sql"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/toshko07/outdoorsy-challenge/internal/db"
 )
 
 var database *sql.DB
 
+// seedTimestamp is the created and updated time of every rental in the test data.
+var seedTimestamp = time.Date(2021, 11, 29, 22, 42, 6, 478595000, time.UTC)
+
 func TestMain(m *testing.M) {
 	var shutdown func()
 	database, shutdown = db.SetupTestDb()
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOFILE -package=$GOPACKAGE
+type Users interface {
+	GetUser(ctx context.Context, id int) (*models.User, error)
+}
+
+type UsersImpl struct {
+	db *sql.DB
+}
+
+func NewUsersRepo(db *sql.DB) Users {
+	return &UsersImpl{db: db}
+}
+
+func (r *UsersImpl) GetUser(ctx context.Context, id int) (*models.User, error) {
+	query := `
+		SELECT
+			id,
+			first_name,
+			last_name
+		FROM users
+		WHERE id = $1`
+
+	var user models.User
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&user.Id, &user.FirstName, &user.LastName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NewNotFoundError(models.UserNotFoundCode, fmt.Sprintf("user with id %d not found", id))
+		}
+
+		return nil, dbError("failed to get user", err)
+	}
+
+	return &user, nil
+}
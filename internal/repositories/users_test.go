@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+)
+
+func TestUsers_GetUser(t *testing.T) {
+	testCases := []struct {
+		name          string
+		id            int
+		expectedUser  *models.User
+		expectedError error
+	}{
+		{
+			name:          "Get existing user",
+			id:            3,
+			expectedUser:  &models.User{Id: 3, FirstName: "Barry", LastName: "Martin"},
+			expectedError: nil,
+		},
+		{
+			name:          "Get non-existing user",
+			id:            404,
+			expectedUser:  nil,
+			expectedError: models.NewNotFoundError(models.UserNotFoundCode, "user with id 404 not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			repo := NewUsersRepo(database)
+
+			// When
+			user, err := repo.GetUser(ctx, tc.id)
+
+			// Then
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedUser, user)
+		})
+	}
+}
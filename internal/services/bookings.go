@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOFILE -package=$GOPACKAGE
+type Bookings interface {
+	GetBooking(ctx context.Context, id int) (*models.Booking, error)
+	CreateBooking(ctx context.Context, booking models.Booking) (*models.Booking, error)
+	UpdateBookingStatus(ctx context.Context, id int, status string) (*models.Booking, error)
+}
+
+type BookingsImpl struct {
+	bookingsRepo   repositories.Bookings
+	usersRepo      repositories.Users
+	rentalsService Rentals
+}
+
+func NewBookingsService(bookingsRepo repositories.Bookings, usersRepo repositories.Users, rentalsService Rentals) Bookings {
+	return &BookingsImpl{bookingsRepo, usersRepo, rentalsService}
+}
+
+func (b *BookingsImpl) GetBooking(ctx context.Context, id int) (*models.Booking, error) {
+	return b.bookingsRepo.GetBooking(ctx, id)
+}
+
+// CreateBooking books booking.RentalId for the requested days as a pending booking, priced at the price per day
+// of the rental for every night, in the currency of the rental. It returns a ConflictError when the rental is not available on any of the days.
+func (b *BookingsImpl) CreateBooking(ctx context.Context, booking models.Booking) (*models.Booking, error) {
+	rental, err := b.rentalsService.GetRental(ctx, booking.RentalId, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.validateBooking(ctx, booking, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	booking.Status = models.BookingStatusPending
+	booking.Total = rental.Price.PerDay * int64(booking.Days())
+	booking.Currency = rental.Price.Currency
+	id, err := b.bookingsRepo.CreateBooking(ctx, booking)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.bookingsRepo.GetBooking(ctx, id)
+}
+
+// UpdateBookingStatus moves the booking to the status, when its current status allows it. Requesting the current
+// status again leaves the booking unchanged.
+func (b *BookingsImpl) UpdateBookingStatus(ctx context.Context, id int, status string) (*models.Booking, error) {
+	if !models.IsBookingStatus(status) {
+		return nil, models.NewBadRequestError("invalid booking status", models.FieldError{
+			Field: "status",
+			Msg:   fmt.Sprintf("must be one of %s, %s or %s", models.BookingStatusPending, models.BookingStatusConfirmed, models.BookingStatusCancelled),
+		})
+	}
+
+	booking, err := b.bookingsRepo.GetBooking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Status == status {
+		return booking, nil
+	}
+	if !models.CanTransition(booking.Status, status) {
+		return nil, models.NewConflictError(models.BookingStatusConflictCode, fmt.Sprintf("booking with id %d can not change from %s to %s", id, booking.Status, status))
+	}
+
+	if err := b.bookingsRepo.UpdateBookingStatus(ctx, id, booking.Status, status); err != nil {
+		return nil, err
+	}
+
+	return b.bookingsRepo.GetBooking(ctx, id)
+}
+
+// validateBooking checks the requested days, starting today at the earliest, and that the renter exists,
+// reporting every invalid field at once.
+func (b *BookingsImpl) validateBooking(ctx context.Context, booking models.Booking, now time.Time) error {
+	var fields []models.FieldError
+	invalid := func(field, msg string) {
+		fields = append(fields, models.FieldError{Field: field, Msg: msg})
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if booking.Start.Before(today) {
+		invalid("start_date", "must not be in the past")
+	}
+	if !booking.End.After(booking.Start) {
+		invalid("end_date", "must be after start_date")
+	} else if booking.Days() > models.MaxAvailabilityDays {
+		invalid("end_date", fmt.Sprintf("must not be more than %d days after start_date", models.MaxAvailabilityDays))
+	}
+
+	if booking.UserId <= 0 {
+		invalid("user_id", "must be a positive id")
+	} else if _, err := b.usersRepo.GetUser(ctx, booking.UserId); err != nil {
+		if !errors.Is(err, models.ErrNotFound) {
+			return err
+		}
+		invalid("user_id", fmt.Sprintf("user with id %d does not exist", booking.UserId))
+	}
+
+	if len(fields) > 0 {
+		return models.NewBadRequestError("invalid booking", fields...)
+	}
+
+	return nil
+}
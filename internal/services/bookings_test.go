@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
+	"go.uber.org/mock/gomock"
+)
+
+func requestedBooking() models.Booking {
+	return models.Booking{
+		RentalId: 1,
+		UserId:   5,
+		DateRange: models.DateRange{
+			Start: time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2030, 8, 4, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func storedBooking(status string) *models.Booking {
+	booking := requestedBooking()
+	booking.Id = 4
+	booking.Status = status
+	booking.Total = 50700
+	booking.Currency = "USD"
+	return &booking
+}
+
+func TestBookings_GetBooking(t *testing.T) {
+	testCases := []struct {
+		name            string
+		id              int
+		expectedBooking *models.Booking
+		expectedError   error
+	}{
+		{
+			name:            "Get an existing booking",
+			id:              4,
+			expectedBooking: storedBooking(models.BookingStatusPending),
+			expectedError:   nil,
+		},
+		{
+			name:            "Get a non-existing booking",
+			id:              404,
+			expectedBooking: nil,
+			expectedError:   models.NewNotFoundError(models.BookingNotFoundCode, "booking with id 404 not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockBookings(ctrl)
+			repo.EXPECT().GetBooking(ctx, tc.id).Return(tc.expectedBooking, tc.expectedError)
+			service := NewBookingsService(repo, repositories.NewMockUsers(ctrl), NewMockRentals(ctrl))
+
+			// When
+			booking, err := service.GetBooking(ctx, tc.id)
+
+			// Then
+			assert.Equal(t, tc.expectedBooking, booking)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestBookings_CreateBooking(t *testing.T) {
+	invalidDates := requestedBooking()
+	invalidDates.Start = time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)
+	invalidDates.End = invalidDates.Start
+	invalidDates.UserId = 0
+
+	tooLong := requestedBooking()
+	tooLong.End = tooLong.Start.AddDate(0, 0, models.MaxAvailabilityDays+1)
+
+	pricedInCAD := storedBooking(models.BookingStatusPending)
+	pricedInCAD.Total = 71700
+	pricedInCAD.Currency = "CAD"
+
+	testCases := []struct {
+		name                string
+		booking             models.Booking
+		rental              models.Rental
+		expectedRentalError error
+		expectedUserError   error
+		expectedCreate      bool
+		expectedCreateError error
+		expectedBooking     *models.Booking
+		expectedError       error
+	}{
+		{
+			name:            "Book an available rental",
+			booking:         requestedBooking(),
+			rental:          models.Rental{Id: 1, Price: models.Price{PerDay: 16900, Currency: "USD"}},
+			expectedCreate:  true,
+			expectedBooking: storedBooking(models.BookingStatusPending),
+			expectedError:   nil,
+		},
+		{
+			name:            "Book a rental priced in another currency",
+			booking:         requestedBooking(),
+			rental:          models.Rental{Id: 1, Price: models.Price{PerDay: 23900, Currency: "CAD"}},
+			expectedCreate:  true,
+			expectedBooking: pricedInCAD,
+			expectedError:   nil,
+		},
+		{
+			name:                "Book a non-existing rental",
+			booking:             requestedBooking(),
+			expectedRentalError: models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 1 not found"),
+			expectedBooking:     nil,
+			expectedError:       models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 1 not found"),
+		},
+		{
+			name:            "Book past and empty dates without a user",
+			booking:         invalidDates,
+			expectedBooking: nil,
+			expectedError: models.NewBadRequestError("invalid booking",
+				models.FieldError{Field: "start_date", Msg: "must not be in the past"},
+				models.FieldError{Field: "end_date", Msg: "must be after start_date"},
+				models.FieldError{Field: "user_id", Msg: "must be a positive id"},
+			),
+		},
+		{
+			name:            "Book more days than allowed",
+			booking:         tooLong,
+			expectedBooking: nil,
+			expectedError: models.NewBadRequestError("invalid booking",
+				models.FieldError{Field: "end_date", Msg: "must not be more than 366 days after start_date"},
+			),
+		},
+		{
+			name:              "Book for a non-existing user",
+			booking:           requestedBooking(),
+			expectedUserError: models.NewNotFoundError(models.UserNotFoundCode, "user with id 5 not found"),
+			expectedBooking:   nil,
+			expectedError:     models.NewBadRequestError("invalid booking", models.FieldError{Field: "user_id", Msg: "user with id 5 does not exist"}),
+		},
+		{
+			name:                "Book an unavailable rental",
+			booking:             requestedBooking(),
+			expectedCreate:      true,
+			expectedCreateError: models.NewConflictError(models.RentalUnavailableCode, "rental with id 1 is not available from 2030-08-01 to 2030-08-04"),
+			expectedBooking:     nil,
+			expectedError:       models.NewConflictError(models.RentalUnavailableCode, "rental with id 1 is not available from 2030-08-01 to 2030-08-04"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockBookings(ctrl)
+			usersRepo := repositories.NewMockUsers(ctrl)
+			rentalsService := NewMockRentals(ctrl)
+			rentalsService.EXPECT().GetRental(ctx, tc.booking.RentalId, "").Return(&tc.rental, tc.expectedRentalError)
+			if tc.expectedRentalError == nil && tc.booking.UserId > 0 {
+				usersRepo.EXPECT().GetUser(ctx, tc.booking.UserId).Return(&models.User{Id: tc.booking.UserId}, tc.expectedUserError)
+			}
+			if tc.expectedCreate {
+				priced := tc.booking
+				priced.Status = models.BookingStatusPending
+				priced.Total = tc.rental.Price.PerDay * int64(priced.Days())
+				priced.Currency = tc.rental.Price.Currency
+				repo.EXPECT().CreateBooking(ctx, priced).Return(4, tc.expectedCreateError)
+			}
+			if tc.expectedBooking != nil {
+				repo.EXPECT().GetBooking(ctx, 4).Return(tc.expectedBooking, nil)
+			}
+			service := NewBookingsService(repo, usersRepo, rentalsService)
+
+			// When
+			booking, err := service.CreateBooking(ctx, tc.booking)
+
+			// Then
+			assert.Equal(t, tc.expectedBooking, booking)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestBookings_UpdateBookingStatus(t *testing.T) {
+	testCases := []struct {
+		name                string
+		status              string
+		currentStatus       string
+		expectedUpdate      bool
+		expectedUpdateError error
+		expectedBooking     *models.Booking
+		expectedError       error
+	}{
+		{
+			name:            "Confirm a pending booking",
+			status:          models.BookingStatusConfirmed,
+			currentStatus:   models.BookingStatusPending,
+			expectedUpdate:  true,
+			expectedBooking: storedBooking(models.BookingStatusConfirmed),
+			expectedError:   nil,
+		},
+		{
+			name:            "Cancel a confirmed booking",
+			status:          models.BookingStatusCancelled,
+			currentStatus:   models.BookingStatusConfirmed,
+			expectedUpdate:  true,
+			expectedBooking: storedBooking(models.BookingStatusCancelled),
+			expectedError:   nil,
+		},
+		{
+			name:            "Confirm a confirmed booking",
+			status:          models.BookingStatusConfirmed,
+			currentStatus:   models.BookingStatusConfirmed,
+			expectedBooking: storedBooking(models.BookingStatusConfirmed),
+			expectedError:   nil,
+		},
+		{
+			name:            "Confirm a cancelled booking",
+			status:          models.BookingStatusConfirmed,
+			currentStatus:   models.BookingStatusCancelled,
+			expectedBooking: nil,
+			expectedError:   models.NewConflictError(models.BookingStatusConflictCode, "booking with id 4 can not change from cancelled to confirmed"),
+		},
+		{
+			name:            "Move a confirmed booking back to pending",
+			status:          models.BookingStatusPending,
+			currentStatus:   models.BookingStatusConfirmed,
+			expectedBooking: nil,
+			expectedError:   models.NewConflictError(models.BookingStatusConflictCode, "booking with id 4 can not change from confirmed to pending"),
+		},
+		{
+			name:                "Cancel a booking changed concurrently",
+			status:              models.BookingStatusCancelled,
+			currentStatus:       models.BookingStatusPending,
+			expectedUpdate:      true,
+			expectedUpdateError: models.NewConflictError(models.BookingStatusConflictCode, "booking with id 4 is no longer pending"),
+			expectedBooking:     nil,
+			expectedError:       models.NewConflictError(models.BookingStatusConflictCode, "booking with id 4 is no longer pending"),
+		},
+		{
+			name:            "Unknown status",
+			status:          "paid",
+			expectedBooking: nil,
+			expectedError: models.NewBadRequestError("invalid booking status",
+				models.FieldError{Field: "status", Msg: "must be one of pending, confirmed or cancelled"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockBookings(ctrl)
+			if tc.currentStatus != "" {
+				repo.EXPECT().GetBooking(ctx, 4).Return(storedBooking(tc.currentStatus), nil)
+			}
+			if tc.expectedUpdate {
+				repo.EXPECT().UpdateBookingStatus(ctx, 4, tc.currentStatus, tc.status).Return(tc.expectedUpdateError)
+			}
+			if tc.expectedUpdate && tc.expectedBooking != nil {
+				repo.EXPECT().GetBooking(ctx, 4).Return(tc.expectedBooking, nil)
+			}
+			service := NewBookingsService(repo, repositories.NewMockUsers(ctrl), NewMockRentals(ctrl))
+
+			// When
+			booking, err := service.UpdateBookingStatus(ctx, 4, tc.status)
+
+			// Then
+			assert.Equal(t, tc.expectedBooking, booking)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOFILE -package=$GOPACKAGE
+type Health interface {
+	// Readiness checks whether the API can serve requests, which it can not without its database, nor once it
+	// is shutting down.
+	Readiness(ctx context.Context) models.Health
+	// ShutDown fails the readiness from now on, so the load balancers stop sending requests before the server stops.
+	ShutDown()
+}
+
+type HealthImpl struct {
+	healthRepo            repositories.Health
+	expectedSchemaVersion int
+	timeout               time.Duration
+	shuttingDown          atomic.Bool
+}
+
+// NewHealthService checks that the database answers within the timeout, with its schema at the expected version.
+func NewHealthService(healthRepo repositories.Health, expectedSchemaVersion int, timeout time.Duration) Health {
+	return &HealthImpl{
+		healthRepo:            healthRepo,
+		expectedSchemaVersion: expectedSchemaVersion,
+		timeout:               timeout,
+	}
+}
+
+func (h *HealthImpl) Readiness(ctx context.Context) models.Health {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	health := models.Health{
+		Status: models.HealthStatusUp,
+		Dependencies: map[string]models.DependencyHealth{
+			models.HealthCheckShutdown:   h.checkShutdown(),
+			models.HealthCheckDatabase:   h.checkDatabase(ctx),
+			models.HealthCheckMigrations: h.checkMigrations(ctx),
+		},
+	}
+	for _, dependency := range health.Dependencies {
+		if dependency.Status != models.HealthStatusUp {
+			health.Status = models.HealthStatusDown
+		}
+	}
+
+	return health
+}
+
+func (h *HealthImpl) ShutDown() {
+	h.shuttingDown.Store(true)
+}
+
+func (h *HealthImpl) checkShutdown() models.DependencyHealth {
+	if h.shuttingDown.Load() {
+		return dependencyDown("shutting down")
+	}
+
+	return dependencyUp()
+}
+
+func (h *HealthImpl) checkDatabase(ctx context.Context) models.DependencyHealth {
+	if err := h.healthRepo.Ping(ctx); err != nil {
+		return unhealthyDatabase(err)
+	}
+
+	return dependencyUp()
+}
+
+func (h *HealthImpl) checkMigrations(ctx context.Context) models.DependencyHealth {
+	version, err := h.healthRepo.SchemaVersion(ctx)
+	if err != nil {
+		return unhealthyDatabase(err)
+	}
+	// A schema ahead of the migrations is the one of a newer release being rolled out, which stays compatible.
+	if version < h.expectedSchemaVersion {
+		return dependencyDown(fmt.Sprintf("schema version is %d, expected at least %d", version, h.expectedSchemaVersion))
+	}
+
+	return dependencyUp()
+}
+
+// unhealthyDatabase logs why the database failed a check, which only the kind of the failure is reported for,
+// as its details are internal.
+func unhealthyDatabase(err error) models.DependencyHealth {
+	log.Warnf("database is not ready: %v", err)
+	if errors.Is(err, models.ErrTimeout) {
+		return dependencyDown("timed out")
+	}
+
+	return dependencyDown("unavailable")
+}
+
+func dependencyUp() models.DependencyHealth {
+	return models.DependencyHealth{Status: models.HealthStatusUp}
+}
+
+func dependencyDown(detail string) models.DependencyHealth {
+	return models.DependencyHealth{Status: models.HealthStatusDown, Detail: detail}
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHealth_Readiness(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		shuttingDown          bool
+		expectedPingError     error
+		expectedSchemaVersion int
+		expectedVersionError  error
+		expectedHealth        models.Health
+	}{
+		{
+			name:                  "Ready",
+			expectedSchemaVersion: 6,
+			expectedHealth: models.Health{
+				Status: models.HealthStatusUp,
+				Dependencies: map[string]models.DependencyHealth{
+					models.HealthCheckShutdown:   {Status: models.HealthStatusUp},
+					models.HealthCheckDatabase:   {Status: models.HealthStatusUp},
+					models.HealthCheckMigrations: {Status: models.HealthStatusUp},
+				},
+			},
+		},
+		{
+			name:                  "Shutting down",
+			shuttingDown:          true,
+			expectedSchemaVersion: 6,
+			expectedHealth: models.Health{
+				Status: models.HealthStatusDown,
+				Dependencies: map[string]models.DependencyHealth{
+					models.HealthCheckShutdown:   {Status: models.HealthStatusDown, Detail: "shutting down"},
+					models.HealthCheckDatabase:   {Status: models.HealthStatusUp},
+					models.HealthCheckMigrations: {Status: models.HealthStatusUp},
+				},
+			},
+		},
+		{
+			name:                  "Schema ahead of the migrations",
+			expectedSchemaVersion: 7,
+			expectedHealth: models.Health{
+				Status: models.HealthStatusUp,
+				Dependencies: map[string]models.DependencyHealth{
+					models.HealthCheckShutdown:   {Status: models.HealthStatusUp},
+					models.HealthCheckDatabase:   {Status: models.HealthStatusUp},
+					models.HealthCheckMigrations: {Status: models.HealthStatusUp},
+				},
+			},
+		},
+		{
+			name:                  "Schema behind the migrations",
+			expectedSchemaVersion: 5,
+			expectedHealth: models.Health{
+				Status: models.HealthStatusDown,
+				Dependencies: map[string]models.DependencyHealth{
+					models.HealthCheckShutdown:   {Status: models.HealthStatusUp},
+					models.HealthCheckDatabase:   {Status: models.HealthStatusUp},
+					models.HealthCheckMigrations: {Status: models.HealthStatusDown, Detail: "schema version is 5, expected at least 6"},
+				},
+			},
+		},
+		{
+			name:                 "Database unreachable",
+			expectedPingError:    models.NewUnavailableError("failed to ping database", errors.New("connection refused")),
+			expectedVersionError: models.NewUnavailableError("failed to get schema version", errors.New("connection refused")),
+			expectedHealth: models.Health{
+				Status: models.HealthStatusDown,
+				Dependencies: map[string]models.DependencyHealth{
+					models.HealthCheckShutdown:   {Status: models.HealthStatusUp},
+					models.HealthCheckDatabase:   {Status: models.HealthStatusDown, Detail: "unavailable"},
+					models.HealthCheckMigrations: {Status: models.HealthStatusDown, Detail: "unavailable"},
+				},
+			},
+		},
+		{
+			name:                 "Database too slow",
+			expectedPingError:    models.NewTimeoutError("failed to ping database", context.DeadlineExceeded),
+			expectedVersionError: models.NewTimeoutError("failed to get schema version", context.DeadlineExceeded),
+			expectedHealth: models.Health{
+				Status: models.HealthStatusDown,
+				Dependencies: map[string]models.DependencyHealth{
+					models.HealthCheckShutdown:   {Status: models.HealthStatusUp},
+					models.HealthCheckDatabase:   {Status: models.HealthStatusDown, Detail: "timed out"},
+					models.HealthCheckMigrations: {Status: models.HealthStatusDown, Detail: "timed out"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockHealth(ctrl)
+			repo.EXPECT().Ping(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+				_, hasDeadline := ctx.Deadline()
+				assert.True(t, hasDeadline, "the database must be checked within the timeout")
+				return tc.expectedPingError
+			})
+			repo.EXPECT().SchemaVersion(gomock.Any()).Return(tc.expectedSchemaVersion, tc.expectedVersionError)
+			service := NewHealthService(repo, 6, time.Second)
+			if tc.shuttingDown {
+				service.ShutDown()
+			}
+
+			// When
+			health := service.Readiness(context.Background())
+
+			// Then
+			assert.Equal(t, tc.expectedHealth, health)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pricing.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pricing.go -destination=mock_pricing.go -package=services
+//
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/toshko07/outdoorsy-challenge/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPricing is a mock of Pricing interface.
+type MockPricing struct {
+	ctrl     *gomock.Controller
+	recorder *MockPricingMockRecorder
+}
+
+// MockPricingMockRecorder is the mock recorder for MockPricing.
+type MockPricingMockRecorder struct {
+	mock *MockPricing
+}
+
+// NewMockPricing creates a new mock instance.
+func NewMockPricing(ctrl *gomock.Controller) *MockPricing {
+	mock := &MockPricing{ctrl: ctrl}
+	mock.recorder = &MockPricingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPricing) EXPECT() *MockPricingMockRecorder {
+	return m.recorder
+}
+
+// Quote mocks base method.
+func (m *MockPricing) Quote(ctx context.Context, rentalId int, dates models.DateRange, guests int) (*models.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, rentalId, dates, guests)
+	ret0, _ := ret[0].(*models.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockPricingMockRecorder) Quote(ctx, rentalId, dates, guests any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockPricing)(nil).Quote), ctx, rentalId, dates, guests)
+}
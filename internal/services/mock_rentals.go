@@ -40,26 +40,85 @@ func (m *MockRentals) EXPECT() *MockRentalsMockRecorder {
 	return m.recorder
 }
 
+// CreateRental mocks base method.
+func (m *MockRentals) CreateRental(ctx context.Context, rental models.Rental) (*models.Rental, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRental", ctx, rental)
+	ret0, _ := ret[0].(*models.Rental)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRental indicates an expected call of CreateRental.
+func (mr *MockRentalsMockRecorder) CreateRental(ctx, rental any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRental", reflect.TypeOf((*MockRentals)(nil).CreateRental), ctx, rental)
+}
+
+// DeleteRental mocks base method.
+func (m *MockRentals) DeleteRental(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRental", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRental indicates an expected call of DeleteRental.
+func (mr *MockRentalsMockRecorder) DeleteRental(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRental", reflect.TypeOf((*MockRentals)(nil).DeleteRental), ctx, id)
+}
+
+// GetAvailability mocks base method.
+func (m *MockRentals) GetAvailability(ctx context.Context, id int, dates models.DateRange) (*models.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailability", ctx, id, dates)
+	ret0, _ := ret[0].(*models.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailability indicates an expected call of GetAvailability.
+func (mr *MockRentalsMockRecorder) GetAvailability(ctx, id, dates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailability", reflect.TypeOf((*MockRentals)(nil).GetAvailability), ctx, id, dates)
+}
+
+// GetFacets mocks base method.
+func (m *MockRentals) GetFacets(ctx context.Context, params models.GetRentalsParams) (*models.RentalFacets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFacets", ctx, params)
+	ret0, _ := ret[0].(*models.RentalFacets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFacets indicates an expected call of GetFacets.
+func (mr *MockRentalsMockRecorder) GetFacets(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFacets", reflect.TypeOf((*MockRentals)(nil).GetFacets), ctx, params)
+}
+
 // GetRental mocks base method.
-func (m *MockRentals) GetRental(ctx context.Context, id int) (*models.Rental, error) {
+func (m *MockRentals) GetRental(ctx context.Context, id int, currency string) (*models.Rental, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRental", ctx, id)
+	ret := m.ctrl.Call(m, "GetRental", ctx, id, currency)
 	ret0, _ := ret[0].(*models.Rental)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRental indicates an expected call of GetRental.
-func (mr *MockRentalsMockRecorder) GetRental(ctx, id any) *gomock.Call {
+func (mr *MockRentalsMockRecorder) GetRental(ctx, id, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRental", reflect.TypeOf((*MockRentals)(nil).GetRental), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRental", reflect.TypeOf((*MockRentals)(nil).GetRental), ctx, id, currency)
 }
 
 // GetRentals mocks base method.
-func (m *MockRentals) GetRentals(ctx context.Context, params models.GetRentalsParams) ([]models.Rental, error) {
+func (m *MockRentals) GetRentals(ctx context.Context, params models.GetRentalsParams) (*models.RentalsPage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRentals", ctx, params)
-	ret0, _ := ret[0].([]models.Rental)
+	ret0, _ := ret[0].(*models.RentalsPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -69,3 +128,33 @@ func (mr *MockRentalsMockRecorder) GetRentals(ctx, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRentals", reflect.TypeOf((*MockRentals)(nil).GetRentals), ctx, params)
 }
+
+// PatchRental mocks base method.
+func (m *MockRentals) PatchRental(ctx context.Context, id int, patch models.RentalPatch) (*models.Rental, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchRental", ctx, id, patch)
+	ret0, _ := ret[0].(*models.Rental)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchRental indicates an expected call of PatchRental.
+func (mr *MockRentalsMockRecorder) PatchRental(ctx, id, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchRental", reflect.TypeOf((*MockRentals)(nil).PatchRental), ctx, id, patch)
+}
+
+// UpdateRental mocks base method.
+func (m *MockRentals) UpdateRental(ctx context.Context, rental models.Rental) (*models.Rental, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRental", ctx, rental)
+	ret0, _ := ret[0].(*models.Rental)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRental indicates an expected call of UpdateRental.
+func (mr *MockRentalsMockRecorder) UpdateRental(ctx, rental any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRental", reflect.TypeOf((*MockRentals)(nil).UpdateRental), ctx, rental)
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOFILE -package=$GOPACKAGE
+type Pricing interface {
+	Quote(ctx context.Context, rentalId int, dates models.DateRange, guests int) (*models.Quote, error)
+}
+
+type PricingImpl struct {
+	pricingRepo    repositories.Pricing
+	rentalsService Rentals
+}
+
+func NewPricingService(pricingRepo repositories.Pricing, rentalsService Rentals) Pricing {
+	return &PricingImpl{pricingRepo, rentalsService}
+}
+
+// Quote prices a stay of the guests at the rental for the dates, applying the pricing rules of the rental.
+func (p *PricingImpl) Quote(ctx context.Context, rentalId int, dates models.DateRange, guests int) (*models.Quote, error) {
+	rental, err := p.rentalsService.GetRental(ctx, rentalId, "")
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := p.pricingRepo.GetPricingRules(ctx, rentalId, dates)
+	if err != nil {
+		return nil, err
+	}
+
+	return priceStay(*rental, *rules, dates, guests)
+}
+
+// priceStay breaks the price of a stay down into line items. Every night is priced at the price of its season,
+// the weekend price on Friday and Saturday nights or the price per day otherwise. The weekly or monthly discount
+// is taken off the nightly prices only, and the tax applies to everything else.
+func priceStay(rental models.Rental, rules models.PricingRules, dates models.DateRange, guests int) (*models.Quote, error) {
+	nights := dates.Days()
+	if nights < rules.MinNights {
+		return nil, models.NewBadRequestError("invalid quote", models.FieldError{
+			Field: "end",
+			Msg:   fmt.Sprintf("must be at least %d nights after start", rules.MinNights),
+		})
+	}
+
+	quote := models.Quote{RentalId: rental.Id, DateRange: dates, Guests: guests, Currency: rental.Price.Currency}
+	add := func(itemType, description string, quantity int, unitAmount int64) {
+		if quantity == 0 || unitAmount == 0 {
+			return
+		}
+		amount := int64(quantity) * unitAmount
+		quote.LineItems = append(quote.LineItems, models.LineItem{
+			Type:        itemType,
+			Description: description,
+			Quantity:    quantity,
+			UnitAmount:  unitAmount,
+			Amount:      amount,
+		})
+		quote.Total += amount
+	}
+
+	regular, weekend := 0, 0
+	seasonal := make([]int, len(rules.Seasons))
+	for night := dates.Start; night.Before(dates.End); night = night.AddDate(0, 0, 1) {
+		if season := seasonOf(rules.Seasons, night); season >= 0 {
+			seasonal[season]++
+		} else if rules.WeekendPerDay > 0 && isWeekendNight(night) {
+			weekend++
+		} else {
+			regular++
+		}
+	}
+
+	add(models.LineItemNightly, "Nightly rate", regular, rental.Price.PerDay)
+	add(models.LineItemWeekend, "Weekend rate", weekend, rules.WeekendPerDay)
+	for i, season := range rules.Seasons {
+		description := fmt.Sprintf("Seasonal rate from %s to %s", season.Start.Format(models.DateLayout), season.End.Format(models.DateLayout))
+		add(models.LineItemSeasonal, description, seasonal[i], season.PerDay)
+	}
+
+	nightly := quote.Total
+	switch {
+	case nights >= models.MonthlyNights && rules.MonthlyDiscountPercent > 0:
+		description := fmt.Sprintf("Monthly discount of %d%%", rules.MonthlyDiscountPercent)
+		add(models.LineItemMonthlyDiscount, description, 1, -basisPointsOf(nightly, rules.MonthlyDiscountPercent*100))
+	case nights >= models.WeeklyNights && rules.WeeklyDiscountPercent > 0:
+		description := fmt.Sprintf("Weekly discount of %d%%", rules.WeeklyDiscountPercent)
+		add(models.LineItemWeeklyDiscount, description, 1, -basisPointsOf(nightly, rules.WeeklyDiscountPercent*100))
+	}
+
+	if extra := guests - rental.Sleeps; extra > 0 {
+		description := fmt.Sprintf("%d guests beyond the %d the rental sleeps, for %d nights", extra, rental.Sleeps, nights)
+		add(models.LineItemExtraGuests, description, extra*nights, rules.ExtraGuestPerDay)
+	}
+
+	add(models.LineItemCleaningFee, "Cleaning fee", 1, rules.CleaningFee)
+
+	if rules.TaxRate > 0 {
+		description := fmt.Sprintf("Tax of %s", formatBasisPoints(rules.TaxRate))
+		if rental.Location.State != "" {
+			description = fmt.Sprintf("%s tax of %s", rental.Location.State, formatBasisPoints(rules.TaxRate))
+		}
+		add(models.LineItemTax, description, 1, basisPointsOf(quote.Total, rules.TaxRate))
+	}
+
+	return &quote, nil
+}
+
+// seasonOf returns the index of the season the night is in, or -1 when it is in none.
+func seasonOf(seasons []models.SeasonalPrice, night time.Time) int {
+	for i, season := range seasons {
+		if !night.Before(season.Start) && night.Before(season.End) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isWeekendNight reports whether the night starting on the day is a Friday or Saturday night.
+func isWeekendNight(day time.Time) bool {
+	return day.Weekday() == time.Friday || day.Weekday() == time.Saturday
+}
+
+// basisPointsOf returns the share of the amount, rounded to the nearest cent.
+func basisPointsOf(amount int64, basisPoints int) int64 {
+	return (amount*int64(basisPoints) + 5000) / 10000
+}
+
+func formatBasisPoints(basisPoints int) string {
+	return fmt.Sprintf("%d.%02d%%", basisPoints/100, basisPoints%100)
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
+	"go.uber.org/mock/gomock"
+)
+
+func quotedRental() models.Rental {
+	return models.Rental{
+		Id:       1,
+		Sleeps:   4,
+		Price:    models.Price{PerDay: 16900, Currency: "USD"},
+		Location: models.Location{State: "CA", Country: "US"},
+	}
+}
+
+func pricingRules() models.PricingRules {
+	return models.PricingRules{
+		RentalId:      1,
+		WeekendPerDay: 19900,
+		Seasons: []models.SeasonalPrice{
+			{
+				DateRange: models.DateRange{Start: time.Date(2030, 12, 20, 0, 0, 0, 0, time.UTC), End: time.Date(2031, 1, 3, 0, 0, 0, 0, time.UTC)},
+				PerDay:    24900,
+			},
+		},
+		WeeklyDiscountPercent:  10,
+		MonthlyDiscountPercent: 20,
+		CleaningFee:            7500,
+		ExtraGuestPerDay:       1500,
+		MinNights:              2,
+		TaxRate:                725,
+	}
+}
+
+func stay(start, end time.Time) models.DateRange {
+	return models.DateRange{Start: start, End: end}
+}
+
+func TestPricing_PriceStay(t *testing.T) {
+	august := func(day int) time.Time { return time.Date(2030, 8, day, 0, 0, 0, 0, time.UTC) }
+
+	testCases := []struct {
+		name              string
+		rules             models.PricingRules
+		dates             models.DateRange
+		guests            int
+		expectedLineItems []models.LineItem
+		expectedTotal     int64
+		expectedError     error
+	}{
+		{
+			name:   "Weekend nights at the weekend rate",
+			rules:  pricingRules(),
+			dates:  stay(august(1), august(4)),
+			guests: 2,
+			expectedLineItems: []models.LineItem{
+				{Type: models.LineItemNightly, Description: "Nightly rate", Quantity: 1, UnitAmount: 16900, Amount: 16900},
+				{Type: models.LineItemWeekend, Description: "Weekend rate", Quantity: 2, UnitAmount: 19900, Amount: 39800},
+				{Type: models.LineItemCleaningFee, Description: "Cleaning fee", Quantity: 1, UnitAmount: 7500, Amount: 7500},
+				{Type: models.LineItemTax, Description: "CA tax of 7.25%", Quantity: 1, UnitAmount: 4655, Amount: 4655},
+			},
+			expectedTotal: 68855,
+		},
+		{
+			name:   "Weekly discount and extra guests",
+			rules:  pricingRules(),
+			dates:  stay(august(1), august(8)),
+			guests: 6,
+			expectedLineItems: []models.LineItem{
+				{Type: models.LineItemNightly, Description: "Nightly rate", Quantity: 5, UnitAmount: 16900, Amount: 84500},
+				{Type: models.LineItemWeekend, Description: "Weekend rate", Quantity: 2, UnitAmount: 19900, Amount: 39800},
+				{Type: models.LineItemWeeklyDiscount, Description: "Weekly discount of 10%", Quantity: 1, UnitAmount: -12430, Amount: -12430},
+				{Type: models.LineItemExtraGuests, Description: "2 guests beyond the 4 the rental sleeps, for 7 nights", Quantity: 14, UnitAmount: 1500, Amount: 21000},
+				{Type: models.LineItemCleaningFee, Description: "Cleaning fee", Quantity: 1, UnitAmount: 7500, Amount: 7500},
+				{Type: models.LineItemTax, Description: "CA tax of 7.25%", Quantity: 1, UnitAmount: 10177, Amount: 10177},
+			},
+			expectedTotal: 150547,
+		},
+		{
+			name:   "Monthly discount instead of the weekly one",
+			rules:  pricingRules(),
+			dates:  stay(august(1), time.Date(2030, 9, 1, 0, 0, 0, 0, time.UTC)),
+			guests: 4,
+			expectedLineItems: []models.LineItem{
+				{Type: models.LineItemNightly, Description: "Nightly rate", Quantity: 21, UnitAmount: 16900, Amount: 354900},
+				{Type: models.LineItemWeekend, Description: "Weekend rate", Quantity: 10, UnitAmount: 19900, Amount: 199000},
+				{Type: models.LineItemMonthlyDiscount, Description: "Monthly discount of 20%", Quantity: 1, UnitAmount: -110780, Amount: -110780},
+				{Type: models.LineItemCleaningFee, Description: "Cleaning fee", Quantity: 1, UnitAmount: 7500, Amount: 7500},
+				{Type: models.LineItemTax, Description: "CA tax of 7.25%", Quantity: 1, UnitAmount: 32670, Amount: 32670},
+			},
+			expectedTotal: 483290,
+		},
+		{
+			name:   "Seasonal rate replaces the weekend rate",
+			rules:  pricingRules(),
+			dates:  stay(time.Date(2030, 12, 18, 0, 0, 0, 0, time.UTC), time.Date(2030, 12, 22, 0, 0, 0, 0, time.UTC)),
+			guests: 4,
+			expectedLineItems: []models.LineItem{
+				{Type: models.LineItemNightly, Description: "Nightly rate", Quantity: 2, UnitAmount: 16900, Amount: 33800},
+				{Type: models.LineItemSeasonal, Description: "Seasonal rate from 2030-12-20 to 2031-01-03", Quantity: 2, UnitAmount: 24900, Amount: 49800},
+				{Type: models.LineItemCleaningFee, Description: "Cleaning fee", Quantity: 1, UnitAmount: 7500, Amount: 7500},
+				{Type: models.LineItemTax, Description: "CA tax of 7.25%", Quantity: 1, UnitAmount: 6605, Amount: 6605},
+			},
+			expectedTotal: 97705,
+		},
+		{
+			name:   "Rental without pricing rules",
+			rules:  models.PricingRules{RentalId: 1, MinNights: 1},
+			dates:  stay(august(1), august(4)),
+			guests: 8,
+			expectedLineItems: []models.LineItem{
+				{Type: models.LineItemNightly, Description: "Nightly rate", Quantity: 3, UnitAmount: 16900, Amount: 50700},
+			},
+			expectedTotal: 50700,
+		},
+		{
+			name:   "Stay shorter than the minimum nights",
+			rules:  pricingRules(),
+			dates:  stay(august(1), august(2)),
+			guests: 1,
+			expectedError: models.NewBadRequestError("invalid quote",
+				models.FieldError{Field: "end", Msg: "must be at least 2 nights after start"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// When
+			quote, err := priceStay(quotedRental(), tc.rules, tc.dates, tc.guests)
+
+			// Then
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, tc.expectedLineItems, quote.LineItems)
+				assert.Equal(t, tc.expectedTotal, quote.Total)
+				assert.Equal(t, tc.dates, quote.DateRange)
+				assert.Equal(t, tc.guests, quote.Guests)
+				assert.Equal(t, "USD", quote.Currency)
+			}
+		})
+	}
+}
+
+func TestPricing_Quote(t *testing.T) {
+	dates := stay(time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 8, 4, 0, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name                string
+		expectedRentalError error
+		expectedRulesError  error
+		expectedTotal       int64
+		expectedError       error
+	}{
+		{
+			name:          "Quote an existing rental",
+			expectedTotal: 68855,
+		},
+		{
+			name:                "Quote a non-existing rental",
+			expectedRentalError: models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 1 not found"),
+			expectedError:       models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 1 not found"),
+		},
+		{
+			name:               "Internal error when getting the pricing rules",
+			expectedRulesError: models.NewInternalError("failed to query", errors.New("connection reset")),
+			expectedError:      models.NewInternalError("failed to query", errors.New("connection reset")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			rental := quotedRental()
+			rules := pricingRules()
+			rentalsService := NewMockRentals(ctrl)
+			rentalsService.EXPECT().GetRental(ctx, 1, "").Return(&rental, tc.expectedRentalError)
+			repo := repositories.NewMockPricing(ctrl)
+			if tc.expectedRentalError == nil {
+				repo.EXPECT().GetPricingRules(ctx, 1, dates).Return(&rules, tc.expectedRulesError)
+			}
+			service := NewPricingService(repo, rentalsService)
+
+			// When
+			quote, err := service.Quote(ctx, 1, dates, 2)
+
+			// Then
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, 1, quote.RentalId)
+				assert.Equal(t, tc.expectedTotal, quote.Total)
+			} else {
+				assert.Nil(t, quote)
+			}
+		})
+	}
+}
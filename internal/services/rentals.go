@@ -2,29 +2,289 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/toshko07/outdoorsy-challenge/internal/metrics"
 	"github.com/toshko07/outdoorsy-challenge/internal/models"
 	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=mock_$GOFILE -package=$GOPACKAGE
 type Rentals interface {
-	GetRental(ctx context.Context, id int) (*models.Rental, error)
-	GetRentals(ctx context.Context, params models.GetRentalsParams) ([]models.Rental, error)
+	GetRental(ctx context.Context, id int, currency string) (*models.Rental, error)
+	GetRentals(ctx context.Context, params models.GetRentalsParams) (*models.RentalsPage, error)
+	GetFacets(ctx context.Context, params models.GetRentalsParams) (*models.RentalFacets, error)
+	CreateRental(ctx context.Context, rental models.Rental) (*models.Rental, error)
+	UpdateRental(ctx context.Context, rental models.Rental) (*models.Rental, error)
+	PatchRental(ctx context.Context, id int, patch models.RentalPatch) (*models.Rental, error)
+	DeleteRental(ctx context.Context, id int) error
+	GetAvailability(ctx context.Context, id int, dates models.DateRange) (*models.Availability, error)
 }
 
 type RentalsImpl struct {
-	rentalsRepo repositories.Rentals
+	rentalsRepo   repositories.Rentals
+	usersRepo     repositories.Users
+	exchangeRates repositories.ExchangeRates
 }
 
-func NewRentalsService(rentalsRepo repositories.Rentals) Rentals {
-	return &RentalsImpl{rentalsRepo}
+func NewRentalsService(rentalsRepo repositories.Rentals, usersRepo repositories.Users, exchangeRates repositories.ExchangeRates) Rentals {
+	return &RentalsImpl{rentalsRepo, usersRepo, exchangeRates}
 }
 
-func (r *RentalsImpl) GetRental(ctx context.Context, id int) (*models.Rental, error) {
+// GetRental returns the rental with the id, with its price converted into the currency unless it is empty.
+func (r *RentalsImpl) GetRental(ctx context.Context, id int, currency string) (*models.Rental, error) {
+	if currency == "" {
+		return r.rentalsRepo.GetRental(ctx, id)
+	}
+
+	rates, err := r.ratesInto(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	rental, err := r.rentalsRepo.GetRental(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := convertPrice(&rental.Price, rates, currency); err != nil {
+		return nil, err
+	}
+
+	return rental, nil
+}
+
+func (r *RentalsImpl) GetRentals(ctx context.Context, params models.GetRentalsParams) (*models.RentalsPage, error) {
+	if params.Limit == 0 {
+		params.Limit = models.DefaultLimit
+	}
+	if params.Limit > models.MaxLimit {
+		return nil, models.NewBadRequestError("invalid query parameters", models.FieldError{
+			Field: "limit",
+			Msg:   fmt.Sprintf("must not be greater than %d", models.MaxLimit),
+		})
+	}
+
+	if params.After != nil && params.After.Filter != params.Fingerprint() {
+		return nil, models.NewBadRequestError("invalid query parameters", models.FieldError{
+			Field: "cursor",
+			Msg:   "was issued for a different search",
+		})
+	}
+
+	metrics.CountSearch(params)
+
+	if params.Currency != "" {
+		rates, err := r.ratesInto(ctx, params.Currency)
+		if err != nil {
+			return nil, err
+		}
+		params.ExchangeRates = rates
+	}
+
+	// One rental more than requested tells whether there is a next page to hand out a cursor for.
+	limit := params.Limit
+	params.Limit++
+	rentals, err := r.rentalsRepo.GetRentals(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	params.Limit = limit
+
+	// Prices are converted before the cursor is taken, as the search sorts by the converted price.
+	if params.Currency != "" {
+		for i := range rentals {
+			if err := convertPrice(&rentals[i].Price, params.ExchangeRates, params.Currency); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	page := &models.RentalsPage{
+		Rentals: rentals,
+		Limit:   params.Limit,
+		Offset:  params.Offset,
+	}
+
+	hasMore := len(rentals) > params.Limit
+	if hasMore {
+		page.Rentals = rentals[:params.Limit]
+		page.NextCursor = models.NewCursor(params, page.Rentals[params.Limit-1])
+	}
+
+	// Without more rentals the page already tells how many match, unless the offset skipped past all of them.
+	// Pages of a cursor do not know how many rentals came before them, so these are always counted.
+	if params.After == nil && !hasMore && (len(rentals) > 0 || params.Offset == 0) {
+		page.Total = params.Offset + len(rentals)
+		return page, nil
+	}
+
+	page.Total, err = r.rentalsRepo.CountRentals(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// GetFacets summarizes all rentals matching the search, the sort and pagination of the params are ignored.
+func (r *RentalsImpl) GetFacets(ctx context.Context, params models.GetRentalsParams) (*models.RentalFacets, error) {
+	params.Limit, params.Offset, params.After, params.Sort = 0, 0, nil, nil
+
+	if params.Currency != "" {
+		rates, err := r.ratesInto(ctx, params.Currency)
+		if err != nil {
+			return nil, err
+		}
+		params.ExchangeRates = rates
+	}
+
+	return r.rentalsRepo.GetFacets(ctx, params)
+}
+
+// CreateRental validates and creates the rental owned by rental.User, returning it as stored.
+func (r *RentalsImpl) CreateRental(ctx context.Context, rental models.Rental) (*models.Rental, error) {
+	if err := r.validateRental(ctx, rental); err != nil {
+		return nil, err
+	}
+
+	id, err := r.rentalsRepo.CreateRental(ctx, rental)
+	if err != nil {
+		return nil, err
+	}
+
 	return r.rentalsRepo.GetRental(ctx, id)
 }
 
-func (r *RentalsImpl) GetRentals(ctx context.Context, params models.GetRentalsParams) ([]models.Rental, error) {
-	return r.rentalsRepo.GetRentals(ctx, params)
+// UpdateRental validates and replaces all editable fields of the rental with rental.Id, returning it as stored.
+func (r *RentalsImpl) UpdateRental(ctx context.Context, rental models.Rental) (*models.Rental, error) {
+	if err := r.validateRental(ctx, rental); err != nil {
+		return nil, err
+	}
+
+	if err := r.rentalsRepo.UpdateRental(ctx, rental); err != nil {
+		return nil, err
+	}
+
+	return r.rentalsRepo.GetRental(ctx, rental.Id)
+}
+
+// PatchRental applies the patch to the rental with the id and validates the result before storing it.
+func (r *RentalsImpl) PatchRental(ctx context.Context, id int, patch models.RentalPatch) (*models.Rental, error) {
+	rental, err := r.rentalsRepo.GetRental(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patch.Apply(rental)
+	return r.UpdateRental(ctx, *rental)
+}
+
+func (r *RentalsImpl) DeleteRental(ctx context.Context, id int) error {
+	return r.rentalsRepo.DeleteRental(ctx, id)
+}
+
+// GetAvailability returns the periods within the range the existing rental with the id is booked or blocked.
+func (r *RentalsImpl) GetAvailability(ctx context.Context, id int, dates models.DateRange) (*models.Availability, error) {
+	if _, err := r.rentalsRepo.GetRental(ctx, id); err != nil {
+		return nil, err
+	}
+
+	unavailable, err := r.rentalsRepo.GetUnavailablePeriods(ctx, id, dates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Availability{RentalId: id, DateRange: dates, Unavailable: unavailable}, nil
+}
+
+// minRentalYear is the oldest vehicle year accepted for a rental.
+const minRentalYear = 1900
+
+// validateRental checks the editable fields of the rental and that its owner exists,
+// reporting every invalid field at once.
+func (r *RentalsImpl) validateRental(ctx context.Context, rental models.Rental) error {
+	var fields []models.FieldError
+	invalid := func(field, msg string) {
+		fields = append(fields, models.FieldError{Field: field, Msg: msg})
+	}
+
+	if strings.TrimSpace(rental.Name) == "" {
+		invalid("name", "must not be empty")
+	}
+	if strings.TrimSpace(rental.Type) == "" {
+		invalid("type", "must not be empty")
+	}
+	if maxYear := time.Now().Year() + 1; rental.Year < minRentalYear || rental.Year > maxYear {
+		invalid("year", fmt.Sprintf("must be between %d and %d", minRentalYear, maxYear))
+	}
+	if rental.Length <= 0 {
+		invalid("length", "must be greater than 0")
+	}
+	if rental.Sleeps <= 0 {
+		invalid("sleeps", "must be greater than 0")
+	}
+	if rental.Price.PerDay <= 0 {
+		invalid("price.day", "must be greater than 0")
+	}
+	if !models.IsCurrencyCode(rental.Price.Currency) {
+		invalid("price.currency", "must be a three letter ISO 4217 currency code")
+	} else if _, err := r.exchangeRates.Rates(ctx, rental.Price.Currency); err != nil {
+		if !errors.Is(err, models.ErrNotFound) {
+			return err
+		}
+		invalid("price.currency", fmt.Sprintf("currency '%s' is not supported", rental.Price.Currency))
+	}
+	if rental.Location.Lat < -90 || rental.Location.Lat > 90 {
+		invalid("location.lat", "must be between -90 and 90")
+	}
+	if rental.Location.Lng < -180 || rental.Location.Lng > 180 {
+		invalid("location.lng", "must be between -180 and 180")
+	}
+
+	if rental.User.Id <= 0 {
+		invalid("user_id", "must be a positive id")
+	} else if _, err := r.usersRepo.GetUser(ctx, rental.User.Id); err != nil {
+		if !errors.Is(err, models.ErrNotFound) {
+			return err
+		}
+		invalid("user_id", fmt.Sprintf("user with id %d does not exist", rental.User.Id))
+	}
+
+	if len(fields) > 0 {
+		return models.NewBadRequestError("invalid rental", fields...)
+	}
+
+	return nil
+}
+
+// ratesInto looks up the exchange rates into the requested currency, which is a bad request when it is not supported.
+func (r *RentalsImpl) ratesInto(ctx context.Context, currency string) (map[string]float64, error) {
+	rates, err := r.exchangeRates.Rates(ctx, currency)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, models.NewBadRequestError("invalid query parameters", models.FieldError{
+				Field: "currency",
+				Msg:   fmt.Sprintf("currency '%s' is not supported", currency),
+			})
+		}
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// convertPrice converts the price into the currency with the rate of its native currency.
+func convertPrice(price *models.Price, rates map[string]float64, currency string) error {
+	rate, ok := rates[price.Currency]
+	if !ok {
+		return models.NewInternalError(fmt.Sprintf("no exchange rate from '%s' to '%s'", price.Currency, currency), nil)
+	}
+
+	price.PerDay = models.ConvertAmount(price.PerDay, rate)
+	price.Currency = currency
+	return nil
 }
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/toshko07/outdoorsy-challenge/internal/models"
@@ -31,17 +34,17 @@ func TestRetails_GetRetail(t *testing.T) {
 			name:                 "Get non-existing rental",
 			id:                   404,
 			expectedRepoResponse: nil,
-			expectedRepoError:    models.NewNotFoundError("rental with id 404 not found"),
+			expectedRepoError:    models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 404 not found"),
 			expectedRental:       nil,
-			expectedError:        models.NewNotFoundError("rental with id 404 not found"),
+			expectedError:        models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 404 not found"),
 		},
 		{
 			name:                 "Internal error",
 			id:                   500,
 			expectedRepoResponse: nil,
-			expectedRepoError:    models.NewInternalError("internal error"),
+			expectedRepoError:    models.NewInternalError("failed to query", errors.New("connection reset")),
 			expectedRental:       nil,
-			expectedError:        models.NewInternalError("internal error"),
+			expectedError:        models.NewInternalError("failed to query", errors.New("connection reset")),
 		},
 	}
 
@@ -52,10 +55,10 @@ func TestRetails_GetRetail(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			repo := repositories.NewMockRentals(ctrl)
 			repo.EXPECT().GetRental(ctx, tc.id).Return(tc.expectedRepoResponse, tc.expectedRepoError)
-			service := NewRentalsService(repo)
+			service := NewRentalsService(repo, repositories.NewMockUsers(ctrl), repositories.NewMockExchangeRates(ctrl))
 
 			// When
-			rental, err := service.GetRental(ctx, tc.id)
+			rental, err := service.GetRental(ctx, tc.id, "")
 
 			// Then
 			assert.Equal(t, tc.expectedRental, rental)
@@ -65,37 +68,580 @@ func TestRetails_GetRetail(t *testing.T) {
 }
 
 func TestRetails_GetRentals(t *testing.T) {
+	cursor := models.NewCursor(models.GetRentalsParams{PriceMin: 9000}, models.Rental{Id: 6})
+
 	testCases := []struct {
-		name                 string
-		params               models.GetRentalsParams
-		expectedRepoResponse []models.Rental
-		expectedRepoError    error
-		expectedRentals      []models.Rental
-		expectedError        error
+		name                  string
+		params                models.GetRentalsParams
+		expectedRepoParams    models.GetRentalsParams
+		expectedRepoResponse  []models.Rental
+		expectedRepoError     error
+		expectedCount         bool
+		expectedCountResponse int
+		expectedCountError    error
+		expectedPage          *models.RentalsPage
+		expectedError         error
 	}{
 		{
-			name:                 "Get existing rentals",
+			name:                 "Get existing rentals with the default limit",
 			params:               models.GetRentalsParams{},
-			expectedRepoResponse: []models.Rental{},
+			expectedRepoParams:   models.GetRentalsParams{Limit: models.DefaultLimit},
+			expectedRepoResponse: []models.Rental{{Id: 1}, {Id: 2}},
 			expectedRepoError:    nil,
-			expectedRentals:      []models.Rental{},
+			expectedPage:         &models.RentalsPage{Rentals: []models.Rental{{Id: 1}, {Id: 2}}, Total: 2, Limit: models.DefaultLimit},
 			expectedError:        nil,
 		},
+		{
+			name:                  "Get a full page of rentals",
+			params:                models.GetRentalsParams{Limit: 2, Offset: 4},
+			expectedRepoParams:    models.GetRentalsParams{Limit: 2, Offset: 4},
+			expectedRepoResponse:  []models.Rental{{Id: 5}, {Id: 6}, {Id: 7}},
+			expectedRepoError:     nil,
+			expectedCount:         true,
+			expectedCountResponse: 30,
+			expectedPage: &models.RentalsPage{
+				Rentals:    []models.Rental{{Id: 5}, {Id: 6}},
+				Total:      30,
+				Limit:      2,
+				Offset:     4,
+				NextCursor: models.NewCursor(models.GetRentalsParams{}, models.Rental{Id: 6}),
+			},
+			expectedError: nil,
+		},
+		{
+			name:                  "Get the last page after a cursor",
+			params:                models.GetRentalsParams{PriceMin: 9000, Limit: 2, After: cursor},
+			expectedRepoParams:    models.GetRentalsParams{PriceMin: 9000, Limit: 2, After: cursor},
+			expectedRepoResponse:  []models.Rental{{Id: 7}},
+			expectedRepoError:     nil,
+			expectedCount:         true,
+			expectedCountResponse: 7,
+			expectedPage:          &models.RentalsPage{Rentals: []models.Rental{{Id: 7}}, Total: 7, Limit: 2},
+			expectedError:         nil,
+		},
+		{
+			name:                  "Get non-existing rentals past the last page",
+			params:                models.GetRentalsParams{Limit: 2, Offset: 40},
+			expectedRepoParams:    models.GetRentalsParams{Limit: 2, Offset: 40},
+			expectedRepoResponse:  nil,
+			expectedRepoError:     nil,
+			expectedCount:         true,
+			expectedCountResponse: 30,
+			expectedPage:          &models.RentalsPage{Rentals: nil, Total: 30, Limit: 2, Offset: 40},
+			expectedError:         nil,
+		},
 		{
 			name:                 "Get non-existing rentals",
 			params:               models.GetRentalsParams{Ids: []int{404}},
+			expectedRepoParams:   models.GetRentalsParams{Ids: []int{404}, Limit: models.DefaultLimit},
 			expectedRepoResponse: nil,
 			expectedRepoError:    nil,
-			expectedRentals:      nil,
+			expectedPage:         &models.RentalsPage{Rentals: nil, Total: 0, Limit: models.DefaultLimit},
 			expectedError:        nil,
 		},
 		{
 			name:                 "Internal error",
 			params:               models.GetRentalsParams{},
+			expectedRepoParams:   models.GetRentalsParams{Limit: models.DefaultLimit},
 			expectedRepoResponse: nil,
-			expectedRepoError:    models.NewInternalError("internal error"),
-			expectedRentals:      nil,
-			expectedError:        models.NewInternalError("internal error"),
+			expectedRepoError:    models.NewInternalError("failed to query", errors.New("connection reset")),
+			expectedPage:         nil,
+			expectedError:        models.NewInternalError("failed to query", errors.New("connection reset")),
+		},
+		{
+			name:                 "Internal error when counting",
+			params:               models.GetRentalsParams{Limit: 1},
+			expectedRepoParams:   models.GetRentalsParams{Limit: 1},
+			expectedRepoResponse: []models.Rental{{Id: 1}, {Id: 2}},
+			expectedRepoError:    nil,
+			expectedCount:        true,
+			expectedCountError:   models.NewInternalError("failed to query", errors.New("connection reset")),
+			expectedPage:         nil,
+			expectedError:        models.NewInternalError("failed to query", errors.New("connection reset")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockRentals(ctrl)
+			// One rental more than the limit is fetched to tell whether there is a next page.
+			fetchParams := tc.expectedRepoParams
+			fetchParams.Limit++
+			repo.EXPECT().GetRentals(ctx, fetchParams).Return(tc.expectedRepoResponse, tc.expectedRepoError)
+			if tc.expectedCount {
+				repo.EXPECT().CountRentals(ctx, tc.expectedRepoParams).Return(tc.expectedCountResponse, tc.expectedCountError)
+			}
+			service := NewRentalsService(repo, repositories.NewMockUsers(ctrl), repositories.NewMockExchangeRates(ctrl))
+
+			// When
+			page, err := service.GetRentals(ctx, tc.params)
+
+			// Then
+			assert.Equal(t, tc.expectedPage, page)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestRetails_GetRentals_LimitTooLarge(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := repositories.NewMockRentals(ctrl)
+	service := NewRentalsService(repo, repositories.NewMockUsers(ctrl), repositories.NewMockExchangeRates(ctrl))
+
+	// When
+	page, err := service.GetRentals(ctx, models.GetRentalsParams{Limit: models.MaxLimit + 1})
+
+	// Then
+	assert.Nil(t, page)
+	assert.Equal(t, models.NewBadRequestError("invalid query parameters", models.FieldError{Field: "limit", Msg: "must not be greater than 100"}), err)
+}
+
+func TestRetails_GetRentals_CursorOfAnotherSearch(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := repositories.NewMockRentals(ctrl)
+	service := NewRentalsService(repo, repositories.NewMockUsers(ctrl), repositories.NewMockExchangeRates(ctrl))
+	cursor := models.NewCursor(models.GetRentalsParams{PriceMin: 9000}, models.Rental{Id: 6})
+
+	// When
+	page, err := service.GetRentals(ctx, models.GetRentalsParams{PriceMin: 10000, After: cursor})
+
+	// Then
+	assert.Nil(t, page)
+	assert.Equal(t, models.NewBadRequestError("invalid query parameters", models.FieldError{Field: "cursor", Msg: "was issued for a different search"}), err)
+}
+
+func TestRetails_GetRental_InCurrency(t *testing.T) {
+	eurRates := map[string]float64{"EUR": 1, "USD": 0.9}
+
+	testCases := []struct {
+		name           string
+		currency       string
+		expectedRates  map[string]float64
+		expectedError  error
+		stored         models.Rental
+		expectedRental *models.Rental
+	}{
+		{
+			name:           "Get a rental in another currency",
+			currency:       "EUR",
+			expectedRates:  eurRates,
+			stored:         models.Rental{Id: 1, Price: models.Price{PerDay: 16900, Currency: "USD"}},
+			expectedRental: &models.Rental{Id: 1, Price: models.Price{PerDay: 15210, Currency: "EUR"}},
+		},
+		{
+			name:           "Get a rental in its own currency",
+			currency:       "EUR",
+			expectedRates:  eurRates,
+			stored:         models.Rental{Id: 11, Price: models.Price{PerDay: 8900, Currency: "EUR"}},
+			expectedRental: &models.Rental{Id: 11, Price: models.Price{PerDay: 8900, Currency: "EUR"}},
+		},
+		{
+			name:           "Get a rental in a currency without a rate for its own",
+			currency:       "EUR",
+			expectedRates:  eurRates,
+			stored:         models.Rental{Id: 26, Price: models.Price{PerDay: 11000, Currency: "AUD"}},
+			expectedRental: nil,
+			expectedError:  models.NewInternalError("no exchange rate from 'AUD' to 'EUR'", nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockRentals(ctrl)
+			exchangeRates := repositories.NewMockExchangeRates(ctrl)
+			exchangeRates.EXPECT().Rates(ctx, tc.currency).Return(tc.expectedRates, nil)
+			stored := tc.stored
+			repo.EXPECT().GetRental(ctx, stored.Id).Return(&stored, nil)
+			service := NewRentalsService(repo, repositories.NewMockUsers(ctrl), exchangeRates)
+
+			// When
+			rental, err := service.GetRental(ctx, stored.Id, tc.currency)
+
+			// Then
+			assert.Equal(t, tc.expectedRental, rental)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestRetails_GetRental_UnsupportedCurrency(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	exchangeRates := repositories.NewMockExchangeRates(ctrl)
+	exchangeRates.EXPECT().Rates(ctx, "XYZ").Return(nil, models.NewNotFoundError(models.ExchangeRatesNotFoundCode, "no exchange rates for currency 'XYZ'"))
+	service := NewRentalsService(repositories.NewMockRentals(ctrl), repositories.NewMockUsers(ctrl), exchangeRates)
+
+	// When
+	rental, err := service.GetRental(ctx, 1, "XYZ")
+
+	// Then
+	assert.Nil(t, rental)
+	assert.Equal(t, models.NewBadRequestError("invalid query parameters", models.FieldError{Field: "currency", Msg: "currency 'XYZ' is not supported"}), err)
+}
+
+func TestRetails_GetRentals_InCurrency(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := repositories.NewMockRentals(ctrl)
+	exchangeRates := repositories.NewMockExchangeRates(ctrl)
+	rates := map[string]float64{"EUR": 1, "USD": 0.9}
+	exchangeRates.EXPECT().Rates(ctx, "EUR").Return(rates, nil)
+	repo.EXPECT().GetRentals(ctx, models.GetRentalsParams{Currency: "EUR", ExchangeRates: rates, Limit: 2}).Return([]models.Rental{
+		{Id: 11, Price: models.Price{PerDay: 8900, Currency: "EUR"}},
+		{Id: 1, Price: models.Price{PerDay: 16900, Currency: "USD"}},
+	}, nil)
+	repo.EXPECT().CountRentals(ctx, models.GetRentalsParams{Currency: "EUR", ExchangeRates: rates, Limit: 1}).Return(2, nil)
+	service := NewRentalsService(repo, repositories.NewMockUsers(ctrl), exchangeRates)
+
+	// When
+	page, err := service.GetRentals(ctx, models.GetRentalsParams{Currency: "EUR", Limit: 1})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Rental{{Id: 11, Price: models.Price{PerDay: 8900, Currency: "EUR"}}}, page.Rentals)
+	assert.Equal(t, 2, page.Total)
+	// The cursor is taken from the converted price the search was sorted by.
+	params := models.GetRentalsParams{Currency: "EUR", ExchangeRates: rates, Limit: 1}
+	assert.Equal(t, models.NewCursor(params, models.Rental{Id: 11, Price: models.Price{PerDay: 8900, Currency: "EUR"}}), page.NextCursor)
+}
+
+func TestRetails_GetRentals_UnsupportedCurrency(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	exchangeRates := repositories.NewMockExchangeRates(ctrl)
+	exchangeRates.EXPECT().Rates(ctx, "XYZ").Return(nil, models.NewNotFoundError(models.ExchangeRatesNotFoundCode, "no exchange rates for currency 'XYZ'"))
+	service := NewRentalsService(repositories.NewMockRentals(ctrl), repositories.NewMockUsers(ctrl), exchangeRates)
+
+	// When
+	page, err := service.GetRentals(ctx, models.GetRentalsParams{Currency: "XYZ"})
+
+	// Then
+	assert.Nil(t, page)
+	assert.Equal(t, models.NewBadRequestError("invalid query parameters", models.FieldError{Field: "currency", Msg: "currency 'XYZ' is not supported"}), err)
+}
+
+func TestRetails_GetFacets(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := repositories.NewMockRentals(ctrl)
+	exchangeRates := repositories.NewMockExchangeRates(ctrl)
+	exchangeRates.EXPECT().Rates(ctx, "USD").Return(usdRates, nil)
+	facets := &models.RentalFacets{Total: 1, Makes: []models.FacetCount{{Value: "Volkswagen", Count: 1}}}
+	repo.EXPECT().GetFacets(ctx, models.GetRentalsParams{Make: "volkswagen", Currency: "USD", ExchangeRates: usdRates}).Return(facets, nil)
+	service := NewRentalsService(repo, repositories.NewMockUsers(ctrl), exchangeRates)
+
+	// When
+	result, err := service.GetFacets(ctx, models.GetRentalsParams{
+		Make:     "volkswagen",
+		Currency: "USD",
+		Limit:    5,
+		Offset:   10,
+		Sort:     []models.SortField{{Field: models.SortFieldPrice}},
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, facets, result)
+}
+
+// usdRates are the exchange rates into USD the tests convert prices with.
+var usdRates = map[string]float64{"USD": 1, "EUR": 1.25}
+
+func validRental() models.Rental {
+	return models.Rental{
+		Name:            "Test Rental",
+		Description:     "Test Description",
+		Type:            "camper-van",
+		Make:            "Volkswagen",
+		Model:           "Westfalia",
+		Year:            1984,
+		Length:          16,
+		Sleeps:          4,
+		PrimaryImageUrl: "https://example.com/image.jpg",
+		Price:           models.Price{PerDay: 18000, Currency: "USD"},
+		Location:        models.Location{City: "San Diego", State: "CA", Zip: "92037", Country: "US", Lat: 32.83, Lng: -117.28},
+		User:            models.User{Id: 3},
+	}
+}
+
+func TestRetails_CreateRental(t *testing.T) {
+	invalidRental := validRental()
+	invalidRental.Name = " "
+	invalidRental.Year = 1800
+	invalidRental.Price.PerDay = 0
+	invalidRental.Location.Lat = 91
+	invalidRental.Location.Lng = -181
+	invalidRental.User.Id = 0
+
+	storedRental := validRental()
+	storedRental.Id = 31
+	storedRental.User = models.User{Id: 3, FirstName: "Barry", LastName: "Martin"}
+
+	testCases := []struct {
+		name                string
+		rental              models.Rental
+		expectedUserError   error
+		expectedCreate      bool
+		expectedCreateError error
+		expectedRental      *models.Rental
+		expectedError       error
+	}{
+		{
+			name:           "Create a valid rental",
+			rental:         validRental(),
+			expectedCreate: true,
+			expectedRental: &storedRental,
+			expectedError:  nil,
+		},
+		{
+			name:           "Create an invalid rental",
+			rental:         invalidRental,
+			expectedRental: nil,
+			expectedError: models.NewBadRequestError("invalid rental",
+				models.FieldError{Field: "name", Msg: "must not be empty"},
+				models.FieldError{Field: "year", Msg: fmt.Sprintf("must be between 1900 and %d", time.Now().Year()+1)},
+				models.FieldError{Field: "price.day", Msg: "must be greater than 0"},
+				models.FieldError{Field: "location.lat", Msg: "must be between -90 and 90"},
+				models.FieldError{Field: "location.lng", Msg: "must be between -180 and 180"},
+				models.FieldError{Field: "user_id", Msg: "must be a positive id"},
+			),
+		},
+		{
+			name:              "Create a rental of a non-existing user",
+			rental:            validRental(),
+			expectedUserError: models.NewNotFoundError(models.UserNotFoundCode, "user with id 3 not found"),
+			expectedRental:    nil,
+			expectedError:     models.NewBadRequestError("invalid rental", models.FieldError{Field: "user_id", Msg: "user with id 3 does not exist"}),
+		},
+		{
+			name:              "Internal error when getting the user",
+			rental:            validRental(),
+			expectedUserError: models.NewInternalError("failed to query", errors.New("connection reset")),
+			expectedRental:    nil,
+			expectedError:     models.NewInternalError("failed to query", errors.New("connection reset")),
+		},
+		{
+			name:                "Internal error when creating",
+			rental:              validRental(),
+			expectedCreate:      true,
+			expectedCreateError: models.NewInternalError("failed to query", errors.New("connection reset")),
+			expectedRental:      nil,
+			expectedError:       models.NewInternalError("failed to query", errors.New("connection reset")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockRentals(ctrl)
+			usersRepo := repositories.NewMockUsers(ctrl)
+			if tc.rental.User.Id > 0 {
+				usersRepo.EXPECT().GetUser(ctx, tc.rental.User.Id).Return(&models.User{Id: tc.rental.User.Id}, tc.expectedUserError)
+			}
+			if tc.expectedCreate {
+				repo.EXPECT().CreateRental(ctx, tc.rental).Return(31, tc.expectedCreateError)
+			}
+			if tc.expectedRental != nil {
+				repo.EXPECT().GetRental(ctx, 31).Return(tc.expectedRental, nil)
+			}
+			exchangeRates := repositories.NewMockExchangeRates(ctrl)
+			exchangeRates.EXPECT().Rates(ctx, "USD").Return(usdRates, nil)
+			service := NewRentalsService(repo, usersRepo, exchangeRates)
+
+			// When
+			rental, err := service.CreateRental(ctx, tc.rental)
+
+			// Then
+			assert.Equal(t, tc.expectedRental, rental)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestRetails_UpdateRental(t *testing.T) {
+	rental := validRental()
+	rental.Id = 3
+
+	testCases := []struct {
+		name                string
+		expectedUpdateError error
+		expectedRental      *models.Rental
+		expectedError       error
+	}{
+		{
+			name:                "Update an existing rental",
+			expectedUpdateError: nil,
+			expectedRental:      &rental,
+			expectedError:       nil,
+		},
+		{
+			name:                "Update a non-existing rental",
+			expectedUpdateError: models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 3 not found"),
+			expectedRental:      nil,
+			expectedError:       models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 3 not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockRentals(ctrl)
+			usersRepo := repositories.NewMockUsers(ctrl)
+			usersRepo.EXPECT().GetUser(ctx, 3).Return(&models.User{Id: 3}, nil)
+			repo.EXPECT().UpdateRental(ctx, rental).Return(tc.expectedUpdateError)
+			if tc.expectedRental != nil {
+				repo.EXPECT().GetRental(ctx, 3).Return(tc.expectedRental, nil)
+			}
+			exchangeRates := repositories.NewMockExchangeRates(ctrl)
+			exchangeRates.EXPECT().Rates(ctx, "USD").Return(usdRates, nil)
+			service := NewRentalsService(repo, usersRepo, exchangeRates)
+
+			// When
+			updated, err := service.UpdateRental(ctx, rental)
+
+			// Then
+			assert.Equal(t, tc.expectedRental, updated)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestRetails_PatchRental(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := repositories.NewMockRentals(ctrl)
+	usersRepo := repositories.NewMockUsers(ctrl)
+	stored := validRental()
+	stored.Id = 3
+	price := int64(20000)
+	lat := 32.9
+	patched := stored
+	patched.Price.PerDay = price
+	patched.Location.Lat = lat
+	repo.EXPECT().GetRental(ctx, 3).Return(&stored, nil)
+	usersRepo.EXPECT().GetUser(ctx, 3).Return(&models.User{Id: 3}, nil)
+	repo.EXPECT().UpdateRental(ctx, patched).Return(nil)
+	repo.EXPECT().GetRental(ctx, 3).Return(&patched, nil)
+	exchangeRates := repositories.NewMockExchangeRates(ctrl)
+	exchangeRates.EXPECT().Rates(ctx, "USD").Return(usdRates, nil)
+	service := NewRentalsService(repo, usersRepo, exchangeRates)
+
+	// When
+	rental, err := service.PatchRental(ctx, 3, models.RentalPatch{PricePerDay: &price, Lat: &lat})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &patched, rental)
+}
+
+func TestRetails_PatchRental_Invalid(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := repositories.NewMockRentals(ctrl)
+	usersRepo := repositories.NewMockUsers(ctrl)
+	stored := validRental()
+	stored.Id = 3
+	sleeps := 0
+	repo.EXPECT().GetRental(ctx, 3).Return(&stored, nil)
+	usersRepo.EXPECT().GetUser(ctx, 3).Return(&models.User{Id: 3}, nil)
+	exchangeRates := repositories.NewMockExchangeRates(ctrl)
+	exchangeRates.EXPECT().Rates(ctx, "USD").Return(usdRates, nil)
+	service := NewRentalsService(repo, usersRepo, exchangeRates)
+
+	// When
+	rental, err := service.PatchRental(ctx, 3, models.RentalPatch{Sleeps: &sleeps})
+
+	// Then
+	assert.Nil(t, rental)
+	assert.Equal(t, models.NewBadRequestError("invalid rental", models.FieldError{Field: "sleeps", Msg: "must be greater than 0"}), err)
+}
+
+func TestRetails_DeleteRental(t *testing.T) {
+	testCases := []struct {
+		name          string
+		id            int
+		expectedError error
+	}{
+		{
+			name:          "Delete an existing rental",
+			id:            1,
+			expectedError: nil,
+		},
+		{
+			name:          "Delete a non-existing rental",
+			id:            404,
+			expectedError: models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 404 not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockRentals(ctrl)
+			repo.EXPECT().DeleteRental(ctx, tc.id).Return(tc.expectedError)
+			service := NewRentalsService(repo, repositories.NewMockUsers(ctrl), repositories.NewMockExchangeRates(ctrl))
+
+			// When
+			err := service.DeleteRental(ctx, tc.id)
+
+			// Then
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestRetails_GetAvailability(t *testing.T) {
+	july := models.DateRange{Start: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC)}
+	booked := []models.UnavailablePeriod{
+		{
+			DateRange: models.DateRange{Start: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 7, 8, 0, 0, 0, 0, time.UTC)},
+			Reason:    models.UnavailableReasonBooked,
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		id                   int
+		expectedRentalError  error
+		expectedPeriods      bool
+		expectedAvailability *models.Availability
+		expectedError        error
+	}{
+		{
+			name:                 "Get the availability of an existing rental",
+			id:                   1,
+			expectedPeriods:      true,
+			expectedAvailability: &models.Availability{RentalId: 1, DateRange: july, Unavailable: booked},
+			expectedError:        nil,
+		},
+		{
+			name:                 "Get the availability of a non-existing rental",
+			id:                   404,
+			expectedRentalError:  models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 404 not found"),
+			expectedAvailability: nil,
+			expectedError:        models.NewNotFoundError(models.RentalNotFoundCode, "rental with id 404 not found"),
 		},
 	}
 
@@ -105,14 +651,17 @@ func TestRetails_GetRentals(t *testing.T) {
 			ctx := context.Background()
 			ctrl := gomock.NewController(t)
 			repo := repositories.NewMockRentals(ctrl)
-			repo.EXPECT().GetRentals(ctx, tc.params).Return(tc.expectedRepoResponse, tc.expectedRepoError)
-			service := NewRentalsService(repo)
+			repo.EXPECT().GetRental(ctx, tc.id).Return(&models.Rental{Id: tc.id}, tc.expectedRentalError)
+			if tc.expectedPeriods {
+				repo.EXPECT().GetUnavailablePeriods(ctx, tc.id, july).Return(booked, nil)
+			}
+			service := NewRentalsService(repo, repositories.NewMockUsers(ctrl), repositories.NewMockExchangeRates(ctrl))
 
 			// When
-			rentals, err := service.GetRentals(ctx, tc.params)
+			availability, err := service.GetAvailability(ctx, tc.id, july)
 
 			// Then
-			assert.Equal(t, tc.expectedRentals, rentals)
+			assert.Equal(t, tc.expectedAvailability, availability)
 			assert.Equal(t, tc.expectedError, err)
 		})
 	}
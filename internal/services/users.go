@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOFILE -package=$GOPACKAGE
+type Users interface {
+	GetUser(ctx context.Context, id int) (*models.User, error)
+	GetUserRentals(ctx context.Context, id int, params models.GetRentalsParams) (*models.RentalsPage, error)
+}
+
+type UsersImpl struct {
+	usersRepo      repositories.Users
+	rentalsService Rentals
+}
+
+func NewUsersService(usersRepo repositories.Users, rentalsService Rentals) Users {
+	return &UsersImpl{usersRepo, rentalsService}
+}
+
+func (u *UsersImpl) GetUser(ctx context.Context, id int) (*models.User, error) {
+	return u.usersRepo.GetUser(ctx, id)
+}
+
+// GetUserRentals searches the rentals owned by an existing user, with the same filters and pagination as GetRentals.
+func (u *UsersImpl) GetUserRentals(ctx context.Context, id int, params models.GetRentalsParams) (*models.RentalsPage, error) {
+	if _, err := u.usersRepo.GetUser(ctx, id); err != nil {
+		return nil, err
+	}
+
+	params.UserId = id
+	return u.rentalsService.GetRentals(ctx, params)
+}
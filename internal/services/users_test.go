@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/toshko07/outdoorsy-challenge/internal/models"
+	"github.com/toshko07/outdoorsy-challenge/internal/repositories"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsers_GetUser(t *testing.T) {
+	testCases := []struct {
+		name              string
+		id                int
+		expectedRepoUser  *models.User
+		expectedRepoError error
+		expectedUser      *models.User
+		expectedError     error
+	}{
+		{
+			name:              "Get existing user",
+			id:                1,
+			expectedRepoUser:  &models.User{Id: 1, FirstName: "John", LastName: "Smith"},
+			expectedRepoError: nil,
+			expectedUser:      &models.User{Id: 1, FirstName: "John", LastName: "Smith"},
+			expectedError:     nil,
+		},
+		{
+			name:              "Get non-existing user",
+			id:                404,
+			expectedRepoUser:  nil,
+			expectedRepoError: models.NewNotFoundError(models.UserNotFoundCode, "user with id 404 not found"),
+			expectedUser:      nil,
+			expectedError:     models.NewNotFoundError(models.UserNotFoundCode, "user with id 404 not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockUsers(ctrl)
+			repo.EXPECT().GetUser(ctx, tc.id).Return(tc.expectedRepoUser, tc.expectedRepoError)
+			service := NewUsersService(repo, NewMockRentals(ctrl))
+
+			// When
+			user, err := service.GetUser(ctx, tc.id)
+
+			// Then
+			assert.Equal(t, tc.expectedUser, user)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestUsers_GetUserRentals(t *testing.T) {
+	testCases := []struct {
+		name              string
+		id                int
+		params            models.GetRentalsParams
+		expectedRepoError error
+		expectedParams    *models.GetRentalsParams
+		expectedPage      *models.RentalsPage
+		expectedError     error
+	}{
+		{
+			name:           "Get the rentals of an existing user",
+			id:             4,
+			params:         models.GetRentalsParams{PriceMax: 10000, Limit: 2},
+			expectedParams: &models.GetRentalsParams{UserId: 4, PriceMax: 10000, Limit: 2},
+			expectedPage:   &models.RentalsPage{Rentals: []models.Rental{{Id: 9}, {Id: 4}}, Total: 2, Limit: 2},
+			expectedError:  nil,
+		},
+		{
+			name:              "Get the rentals of a non-existing user",
+			id:                404,
+			params:            models.GetRentalsParams{},
+			expectedRepoError: models.NewNotFoundError(models.UserNotFoundCode, "user with id 404 not found"),
+			expectedPage:      nil,
+			expectedError:     models.NewNotFoundError(models.UserNotFoundCode, "user with id 404 not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			repo := repositories.NewMockUsers(ctrl)
+			repo.EXPECT().GetUser(ctx, tc.id).Return(&models.User{Id: tc.id}, tc.expectedRepoError)
+			rentalsService := NewMockRentals(ctrl)
+			if tc.expectedParams != nil {
+				rentalsService.EXPECT().GetRentals(ctx, *tc.expectedParams).Return(tc.expectedPage, nil)
+			}
+			service := NewUsersService(repo, rentalsService)
+
+			// When
+			page, err := service.GetUserRentals(ctx, tc.id, tc.params)
+
+			// Then
+			assert.Equal(t, tc.expectedPage, page)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}